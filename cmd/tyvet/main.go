@@ -0,0 +1,20 @@
+// Command tyvet reports type errors in calls to parametric functions written
+// with `ty.Check`. It can be run on its own,
+//
+//	tyvet ./...
+//
+// or with `go vet`:
+//
+//	go vet -vettool=$(which tyvet) ./...
+//
+// See the `tyvet` package for details.
+package main
+
+import (
+	"github.com/BurntSushi/ty/tyvet"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(tyvet.Analyzer)
+}
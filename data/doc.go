@@ -76,6 +76,25 @@ Which guarantees, at run-time, that the following cannot happen:
 	M.SetMapIndex(rkey, rval)
 	M.SetMapIndex(rkey2, rval2)
 
+In practice, the types of a parametric data type are kept in a
+`ty.Instance`, which binds its type variables, and each method checks its
+arguments against them with the parametric type of the method:
+
+	type OrdMap struct {
+		M reflect.Value
+		Keys reflect.Value
+		inst *ty.Instance
+	}
+
+	func (om *OrdMap) Put(key, val interface{}) {
+		chk := om.inst.Check(new(func(ty.A, ty.B)), key, val)
+		rkey, rval := chk.Args[0], chk.Args[1]
+		...
+	}
+
+This way, type errors are reported consistently, naming the method and the
+instance, e.g., `OrdMap<string, int>`.
+
 The result is much more painful library code but only slightly more painful
 client code.
 */
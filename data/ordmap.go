@@ -1,7 +1,9 @@
 package data
 
 import (
+	"fmt"
 	"reflect"
+	"sync/atomic"
 
 	"github.com/BurntSushi/ty"
 )
@@ -9,9 +11,17 @@ import (
 // OrdMap has a parametric type `OrdMap<K, V>` where `K` is the type
 // of the map's keys and `V` is the type of the map's values.
 type OrdMap struct {
-	m            reflect.Value
-	keys         reflect.Value
-	ktype, vtype reflect.Type
+	m    reflect.Value
+	keys reflect.Value
+
+	// The instance of `OrdMap<K, V>` that binds `K` and `V`, written as
+	// `ty.A` and `ty.B`, with which every method checks its arguments.
+	inst *ty.Instance
+
+	// The number of iterations over `om` in progress. `Delete` only shifts
+	// the keys in place when there are none. It is updated atomically, since
+	// reading `om` from multiple goroutines is safe.
+	iterating int32
 }
 
 // OrderedMap returns a new instance of OrdMap instantiated with the key
@@ -29,18 +39,36 @@ type OrdMap struct {
 // the built-in `map`, except for `Delete` which is O(n) in the number of
 // keys.
 func OrderedMap(ktype, vtype interface{}) *OrdMap {
-	// A giant hack to get `Check` to do all the type construction work for us.
 	chk := ty.Check(
-		new(func(*ty.A, *ty.B) (ty.A, ty.B, map[ty.A]ty.B, []ty.A)),
+		new(func(*ty.A, *ty.B)),
 		ktype, vtype)
-	tkey, tval := chk.Returns[0], chk.Returns[1]
-	tmap, tkeys := chk.Returns[2], chk.Returns[3]
+	tk, _ := chk.Lookup(tyA)
+	tv, _ := chk.Lookup(tyB)
+	return OrderedMapOf(tk, tv)
+}
 
+// OrderedMapOf is just like `OrderedMap`, except the key and value types are
+// given as `reflect.Type` values. This is useful when the types are only
+// known at run time, e.g., to create a map from strings to integers:
+//
+//	omap := OrderedMapOf(reflect.TypeOf(""), reflect.TypeOf(0))
+//
+// OrderedMapOf panics with a `TypeError` if either type is nil or if `ktype`
+// cannot be the key type of a map.
+func OrderedMapOf(ktype, vtype reflect.Type) *OrdMap {
+	if ktype == nil || vtype == nil {
+		panic(ty.TypeError(fmt.Sprintf(
+			"The key and value types of an OrdMap cannot be nil "+
+				"(got '%v' and '%v').", ktype, vtype)))
+	}
+	assertKeyType(ktype)
+	inst := ty.NewInstance("OrdMap",
+		map[reflect.Type]reflect.Type{tyA: ktype, tyB: vtype})
+	tsig := inst.Instantiate(new(func() (map[ty.A]ty.B, []ty.A)))
 	return &OrdMap{
-		m:     reflect.MakeMap(tmap),
-		keys:  reflect.MakeSlice(tkeys, 0, 10),
-		ktype: tkey,
-		vtype: tval,
+		m:    reflect.MakeMap(tsig.Out(0)),
+		keys: reflect.MakeSlice(tsig.Out(1), 0, 10),
+		inst: inst,
 	}
 }
 
@@ -50,8 +78,8 @@ func OrderedMap(ktype, vtype interface{}) *OrdMap {
 //
 // Exists returns true if `key` is in the map `om`.
 func (om *OrdMap) Exists(key interface{}) bool {
-	rkey := ty.AssertType(key, om.ktype)
-	return om.exists(rkey)
+	chk := om.inst.Check(new(func(ty.A)), key)
+	return om.exists(chk.Args[0])
 }
 
 func (om *OrdMap) exists(rkey reflect.Value) bool {
@@ -66,8 +94,11 @@ func (om *OrdMap) exists(rkey reflect.Value) bool {
 // If `key` already exists in the map, then its position in the ordering
 // of the map is not changed.
 func (om *OrdMap) Put(key, val interface{}) {
-	rkey := ty.AssertType(key, om.ktype)
-	rval := ty.AssertType(val, om.vtype)
+	chk := om.inst.Check(new(func(ty.A, ty.B)), key, val)
+	om.put(chk.Args[0], chk.Args[1])
+}
+
+func (om *OrdMap) put(rkey, rval reflect.Value) {
 	if !om.exists(rkey) {
 		om.keys = reflect.Append(om.keys, rkey)
 	}
@@ -81,11 +112,8 @@ func (om *OrdMap) Put(key, val interface{}) {
 // Get retrieves the value in the map `om` corresponding to `key`. If the
 // value does not exist, then the zero value of type `V` is returned.
 func (om *OrdMap) Get(key interface{}) interface{} {
-	rkey := ty.AssertType(key, om.ktype)
-	rval := om.m.MapIndex(rkey)
-	if !rval.IsValid() {
-		return om.zeroValue().Interface()
-	}
+	chk := om.inst.Check(new(func(ty.A)), key)
+	rval, _ := om.tryGet(chk.Args[0])
 	return rval.Interface()
 }
 
@@ -97,12 +125,17 @@ func (om *OrdMap) Get(key interface{}) interface{} {
 // reports whether the value exists in the map or not. If the value does
 // not exist, then the zero value of `V` and `false` are returned.
 func (om *OrdMap) TryGet(key interface{}) (interface{}, bool) {
-	rkey := ty.AssertType(key, om.ktype)
+	chk := om.inst.Check(new(func(ty.A)), key)
+	rval, ok := om.tryGet(chk.Args[0])
+	return rval.Interface(), ok
+}
+
+func (om *OrdMap) tryGet(rkey reflect.Value) (reflect.Value, bool) {
 	rval := om.m.MapIndex(rkey)
 	if !rval.IsValid() {
-		return om.zeroValue().Interface(), false
+		return om.zeroValue(), false
 	}
-	return rval.Interface(), true
+	return rval, true
 }
 
 // Delete has a parametric type:
@@ -113,19 +146,21 @@ func (om *OrdMap) TryGet(key interface{}) (interface{}, bool) {
 //
 // N.B. Delete is O(n) in the number of keys.
 func (om *OrdMap) Delete(key interface{}) {
-	rkey := ty.AssertType(key, om.ktype)
+	chk := om.inst.Check(new(func(ty.A)), key)
+	om.delete(chk.Args[0])
+}
 
+func (om *OrdMap) delete(rkey reflect.Value) {
 	// Avoid doing work if we don't need to.
 	if !om.exists(rkey) {
 		return
 	}
 
+	key := rkey.Interface()
 	keysLen := om.keys.Len()
 	for i := 0; i < keysLen; i++ {
 		if key == om.keys.Index(i).Interface() {
-			// om.keys = append(om.keys[:i], om.keys[i+1:]...)
-			om.keys = reflect.AppendSlice(
-				om.keys.Slice(0, i), om.keys.Slice(i+1, keysLen))
+			om.deleteKeyAt(i)
 			break
 		}
 	}
@@ -134,13 +169,34 @@ func (om *OrdMap) Delete(key interface{}) {
 	om.m.SetMapIndex(rkey, reflect.Value{})
 }
 
+// deleteKeyAt removes the key at index `i` from the list of keys. The keys
+// are shifted in place, unless an iteration is in progress, in which case
+// they are copied into a fresh slice so that the iteration keeps seeing the
+// keys it started with.
+func (om *OrdMap) deleteKeyAt(i int) {
+	keysLen := om.keys.Len()
+	if atomic.LoadInt32(&om.iterating) > 0 {
+		keys := reflect.MakeSlice(om.keys.Type(), 0, keysLen)
+		keys = reflect.AppendSlice(keys, om.keys.Slice(0, i))
+		om.keys = reflect.AppendSlice(keys, om.keys.Slice(i+1, keysLen))
+		return
+	}
+	reflect.Copy(om.keys.Slice(i, keysLen), om.keys.Slice(i+1, keysLen))
+	// Clear the last key so that the map doesn't keep it alive.
+	last := om.keys.Index(keysLen - 1)
+	last.Set(reflect.Zero(last.Type()))
+	om.keys = om.keys.Slice(0, keysLen-1)
+}
+
 // Keys has a parametric type:
 //
 //	func (om *OrdMap<K, V>) Keys() []K
 //
 // Keys returns a list of keys in `om` in the order they were inserted.
 //
-// Behavior is undefined if the list is modified by the caller.
+// Behavior is undefined if the list is modified by the caller. The list
+// shares memory with `om`, so it may change when a key is deleted from
+// `om`.
 func (om *OrdMap) Keys() interface{} {
 	return om.keys.Interface()
 }
@@ -153,7 +209,7 @@ func (om *OrdMap) Keys() interface{} {
 // were inserted.
 func (om *OrdMap) Values() interface{} {
 	mlen := om.Len()
-	tvals := reflect.SliceOf(om.vtype)
+	tvals := om.inst.Instantiate(new([]ty.B))
 	rvals := reflect.MakeSlice(tvals, mlen, mlen)
 	for i := 0; i < mlen; i++ {
 		rvals.Index(i).Set(om.m.MapIndex(om.keys.Index(i)))
@@ -161,6 +217,119 @@ func (om *OrdMap) Values() interface{} {
 	return rvals.Interface()
 }
 
+// Each has a parametric type:
+//
+//	func (om *OrdMap<K, V>) Each(f func(K, V))
+//
+// Each calls `f` with every key and value in `om` in the order that the keys
+// were inserted. No copies of the keys or values are made.
+//
+// The map may be modified by `f`. Iteration visits the keys that were in
+// `om` when it began: keys added by `f` are not visited, keys deleted by `f`
+// before their turn are skipped and values updated by `f` are visited with
+// their new value.
+func (om *OrdMap) Each(f interface{}) {
+	chk := om.inst.Check(new(func(func(ty.A, ty.B))), f)
+	rf := chk.Args[0]
+	om.each(false, func(rkey, rval reflect.Value) bool {
+		call(rf, rkey, rval)
+		return true
+	})
+}
+
+// EachWhile has a parametric type:
+//
+//	func (om *OrdMap<K, V>) EachWhile(f func(K, V) bool)
+//
+// EachWhile is just like `Each`, except iteration stops as soon as `f`
+// returns false.
+func (om *OrdMap) EachWhile(f interface{}) {
+	chk := om.inst.Check(new(func(func(ty.A, ty.B) bool)), f)
+	rf := chk.Args[0]
+	om.each(false, func(rkey, rval reflect.Value) bool {
+		return call(rf, rkey, rval)[0].Bool()
+	})
+}
+
+// EachReverse has a parametric type:
+//
+//	func (om *OrdMap<K, V>) EachReverse(f func(K, V))
+//
+// EachReverse is just like `Each`, except keys are visited from the most
+// recently inserted to the least recently inserted.
+func (om *OrdMap) EachReverse(f interface{}) {
+	chk := om.inst.Check(new(func(func(ty.A, ty.B))), f)
+	rf := chk.Args[0]
+	om.each(true, func(rkey, rval reflect.Value) bool {
+		call(rf, rkey, rval)
+		return true
+	})
+}
+
+// All has a parametric type:
+//
+//	func (om *OrdMap<K, V>) All() func(yield func(K, V) bool)
+//
+// All returns an iterator over the keys and values of `om` in insertion
+// order. Since `OrdMap` is not a generic type, the keys and values are
+// given to `yield` as `interface{}`, which makes the iterator an
+// `iter.Seq2[interface{}, interface{}]` that may be used in a range loop:
+//
+//	for key, val := range omap.All() {
+//		fmt.Println(key.(string), val.(int))
+//	}
+//
+// The iterator has the same behavior as `Each` when `om` is modified during
+// iteration.
+func (om *OrdMap) All() func(yield func(interface{}, interface{}) bool) {
+	return func(yield func(interface{}, interface{}) bool) {
+		om.each(false, func(rkey, rval reflect.Value) bool {
+			return yield(rkey.Interface(), rval.Interface())
+		})
+	}
+}
+
+// Backward has a parametric type:
+//
+//	func (om *OrdMap<K, V>) Backward() func(yield func(K, V) bool)
+//
+// Backward is just like `All`, except the iterator visits keys from the most
+// recently inserted to the least recently inserted.
+func (om *OrdMap) Backward() func(yield func(interface{}, interface{}) bool) {
+	return func(yield func(interface{}, interface{}) bool) {
+		om.each(true, func(rkey, rval reflect.Value) bool {
+			return yield(rkey.Interface(), rval.Interface())
+		})
+	}
+}
+
+// each calls `f` on each key and value in `om` until `f` returns false.
+//
+// The key slice is captured before iteration begins. This is safe because
+// `Put` only ever appends beyond its length and `Delete` doesn't modify it
+// in place while an iteration is in progress.
+func (om *OrdMap) each(reverse bool, f func(rkey, rval reflect.Value) bool) {
+	atomic.AddInt32(&om.iterating, 1)
+	defer atomic.AddInt32(&om.iterating, -1)
+
+	keys := om.keys
+	keysLen := keys.Len()
+	for i := 0; i < keysLen; i++ {
+		rkey := keys.Index(i)
+		if reverse {
+			rkey = keys.Index(keysLen - 1 - i)
+		}
+		rval := om.m.MapIndex(rkey)
+		if !rval.IsValid() {
+			// Deleted during iteration.
+			continue
+		}
+		if !f(rkey, rval) {
+			return
+		}
+	}
+}
+
 // Len has a parametric type:
 //
 //	func (om *OrdMap<K, V>) Len() int
@@ -170,6 +339,123 @@ func (om *OrdMap) Len() int {
 	return om.m.Len()
 }
 
+// Clone has a parametric type:
+//
+//	func (om *OrdMap<K, V>) Clone() *OrdMap<K, V>
+//
+// Clone returns a shallow copy of `om` with the same keys in the same order.
+func (om *OrdMap) Clone() *OrdMap {
+	return om.clone()
+}
+
+// Equal has a parametric type:
+//
+//	func (om *OrdMap<K, V>) Equal(other *OrdMap<K, V>) bool
+//
+// Equal returns true if `om` and `other` have the same key and value types,
+// and have the same keys in the same order. Values are compared with
+// `reflect.DeepEqual`. If `other` is nil, Equal returns false.
+func (om *OrdMap) Equal(other *OrdMap) bool {
+	if other == nil || !om.inst.Equal(other.inst) {
+		return false
+	}
+	keysLen := om.keys.Len()
+	if keysLen != other.keys.Len() {
+		return false
+	}
+	for i := 0; i < keysLen; i++ {
+		rkey := om.keys.Index(i)
+		if rkey.Interface() != other.keys.Index(i).Interface() {
+			return false
+		}
+		v1 := om.m.MapIndex(rkey).Interface()
+		v2 := other.m.MapIndex(rkey).Interface()
+		if !reflect.DeepEqual(v1, v2) {
+			return false
+		}
+	}
+	return true
+}
+
+// Merge has a parametric type:
+//
+//	func (om *OrdMap<K, V>) Merge(other *OrdMap<K, V>, conflict func(K, V, V) V)
+//
+// Merge adds every key and value in `other` to `om`, in the order of
+// `other`. When a key exists in both maps, its value becomes the result of
+// `conflict` applied to the key, the value in `om` and the value in `other`.
+// (Its position in `om` is not changed.) If `conflict` is nil, the value in
+// `other` is used. A nil `other` is an empty map.
+//
+// Merge panics with a `TypeError` if `other` has different key or value
+// types than `om`.
+func (om *OrdMap) Merge(other *OrdMap, conflict interface{}) {
+	if other != nil && !om.inst.Equal(other.inst) {
+		panic(ty.TypeError(fmt.Sprintf("Cannot merge %s into %s.",
+			other.inst, om.inst)))
+	}
+
+	var rconflict reflect.Value
+	if conflict != nil {
+		chk := om.inst.Check(
+			new(func(func(ty.A, ty.B, ty.B) ty.B)),
+			conflict)
+		rconflict = chk.Args[0]
+	}
+	if other == nil {
+		return
+	}
+	other.each(false, func(rkey, rval reflect.Value) bool {
+		if rconflict.IsValid() {
+			if rcur := om.m.MapIndex(rkey); rcur.IsValid() {
+				rval = call(rconflict, rkey, rcur, rval)[0]
+			}
+		}
+		om.put(rkey, rval)
+		return true
+	})
+}
+
+// clone returns a shallow copy of `om`.
+func (om *OrdMap) clone() *OrdMap {
+	keysLen := om.keys.Len()
+	c := &OrdMap{
+		m:    reflect.MakeMap(om.m.Type()),
+		keys: reflect.MakeSlice(om.keys.Type(), keysLen, keysLen+10),
+		inst: om.inst,
+	}
+	reflect.Copy(c.keys, om.keys)
+	for i := 0; i < keysLen; i++ {
+		rkey := om.keys.Index(i)
+		c.m.SetMapIndex(rkey, om.m.MapIndex(rkey))
+	}
+	return c
+}
+
 func (om *OrdMap) zeroValue() reflect.Value {
-	return reflect.New(om.vtype).Elem()
+	return reflect.New(om.inst.Instantiate(new(ty.B))).Elem()
+}
+
+var (
+	tyA = reflect.TypeOf(ty.A{})
+	tyB = reflect.TypeOf(ty.B{})
+)
+
+// call calls the function argument `f` with `args`. It panics with a
+// `TypeError` if `f` is nil, which `Check` allows for an untyped nil.
+func call(f reflect.Value, args ...reflect.Value) []reflect.Value {
+	if f.IsNil() {
+		panic(ty.TypeError(fmt.Sprintf(
+			"Cannot call the nil function argument of type '%s'.", f.Type())))
+	}
+	return f.Call(args)
+}
+
+// assertKeyType panics with a `TypeError` if `ktype` cannot be used as the
+// key type of a map.
+func assertKeyType(ktype reflect.Type) {
+	if !ktype.Comparable() {
+		panic(ty.TypeError(fmt.Sprintf(
+			"Type '%s' cannot be used as a map key.", ktype)))
+	}
 }
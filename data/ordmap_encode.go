@@ -0,0 +1,219 @@
+package data
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/BurntSushi/ty"
+)
+
+var (
+	textMarshalerType   = reflect.TypeOf(new(encoding.TextMarshaler)).Elem()
+	textUnmarshalerType = reflect.TypeOf(new(encoding.TextUnmarshaler)).Elem()
+
+	errNotInstantiated = errors.New("data: cannot encode or decode an " +
+		"OrdMap that was not created with OrderedMap")
+)
+
+// MarshalJSON encodes `om` as a JSON object whose members are in the same
+// order as the keys of `om`.
+//
+// Keys are encoded with the same rules as the `encoding/json` package uses
+// for the keys of a built-in `map`: the type of the keys must be a string
+// type, an integer type or implement `encoding.TextMarshaler`.
+//
+// `om` must have been created with `OrderedMap`.
+func (om *OrdMap) MarshalJSON() ([]byte, error) {
+	if om.inst == nil {
+		return nil, errNotInstantiated
+	}
+
+	buf := new(bytes.Buffer)
+	buf.WriteByte('{')
+	keysLen := om.keys.Len()
+	for i := 0; i < keysLen; i++ {
+		rkey := om.keys.Index(i)
+		skey, err := marshalKey(rkey)
+		if err != nil {
+			return nil, err
+		}
+		bkey, err := json.Marshal(skey)
+		if err != nil {
+			return nil, err
+		}
+		bval, err := json.Marshal(om.m.MapIndex(rkey).Interface())
+		if err != nil {
+			return nil, err
+		}
+
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(bkey)
+		buf.WriteByte(':')
+		buf.Write(bval)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a JSON object into `om`, where every member of the
+// object is added to `om` with `Put` in the order it appears in the
+// document. (So that, just like a built-in `map`, keys already in `om` are
+// kept.) Decoding `null` leaves `om` unchanged.
+//
+// Since the key and value types of `om` are needed to decode a document,
+// `om` must have been created with `OrderedMap`.
+func (om *OrdMap) UnmarshalJSON(data []byte) error {
+	if om.inst == nil {
+		return errNotInstantiated
+	}
+	ktype, _ := om.inst.Lookup(tyA)
+	vtype, _ := om.inst.Lookup(tyB)
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("data: cannot decode JSON %v into an OrdMap", tok)
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		rkey, err := unmarshalKey(tok.(string), ktype)
+		if err != nil {
+			return err
+		}
+		rval := reflect.New(vtype)
+		if err := dec.Decode(rval.Interface()); err != nil {
+			return err
+		}
+		om.put(rkey, rval.Elem())
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// GobEncode encodes the keys of `om` followed by its values, both in
+// insertion order.
+//
+// `om` must have been created with `OrderedMap`.
+func (om *OrdMap) GobEncode() ([]byte, error) {
+	if om.inst == nil {
+		return nil, errNotInstantiated
+	}
+
+	buf := new(bytes.Buffer)
+	enc := gob.NewEncoder(buf)
+	if err := enc.Encode(om.keys.Interface()); err != nil {
+		return nil, err
+	}
+	if err := enc.Encode(om.Values()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode decodes data produced by `GobEncode` into `om`. The keys are
+// added to `om` with `Put` in the order they were encoded.
+//
+// Since the key and value types of `om` are needed to decode the data,
+// `om` must have been created with `OrderedMap`.
+func (om *OrdMap) GobDecode(data []byte) error {
+	if om.inst == nil {
+		return errNotInstantiated
+	}
+
+	dec := gob.NewDecoder(bytes.NewReader(data))
+	rkeys := reflect.New(om.inst.Instantiate(new([]ty.A)))
+	rvals := reflect.New(om.inst.Instantiate(new([]ty.B)))
+	if err := dec.Decode(rkeys.Interface()); err != nil {
+		return err
+	}
+	if err := dec.Decode(rvals.Interface()); err != nil {
+		return err
+	}
+
+	rkeys, rvals = rkeys.Elem(), rvals.Elem()
+	if rkeys.Len() != rvals.Len() {
+		return fmt.Errorf("data: gob data has %d keys but %d values",
+			rkeys.Len(), rvals.Len())
+	}
+	for i := 0; i < rkeys.Len(); i++ {
+		om.put(rkeys.Index(i), rvals.Index(i))
+	}
+	return nil
+}
+
+// marshalKey converts a key to the string used as its JSON object member
+// name.
+func marshalKey(rkey reflect.Value) (string, error) {
+	if rkey.Kind() == reflect.String {
+		return rkey.String(), nil
+	}
+	if rkey.Type().Implements(textMarshalerType) {
+		text, err := rkey.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+	switch rkey.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		return strconv.FormatInt(rkey.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rkey.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("data: cannot encode key of type '%s' as JSON",
+		rkey.Type())
+}
+
+// unmarshalKey converts a JSON object member name to a key of type `tkey`.
+func unmarshalKey(skey string, tkey reflect.Type) (reflect.Value, error) {
+	rkey := reflect.New(tkey)
+	if rkey.Type().Implements(textUnmarshalerType) {
+		u := rkey.Interface().(encoding.TextUnmarshaler)
+		if err := u.UnmarshalText([]byte(skey)); err != nil {
+			return reflect.Value{}, err
+		}
+		return rkey.Elem(), nil
+	}
+
+	rkey = rkey.Elem()
+	switch tkey.Kind() {
+	case reflect.String:
+		rkey.SetString(skey)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		n, err := strconv.ParseInt(skey, 10, 64)
+		if err != nil || rkey.OverflowInt(n) {
+			return reflect.Value{}, fmt.Errorf(
+				"data: cannot decode JSON key '%s' into type '%s'", skey, tkey)
+		}
+		rkey.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(skey, 10, 64)
+		if err != nil || rkey.OverflowUint(n) {
+			return reflect.Value{}, fmt.Errorf(
+				"data: cannot decode JSON key '%s' into type '%s'", skey, tkey)
+		}
+		rkey.SetUint(n)
+	default:
+		return reflect.Value{}, fmt.Errorf(
+			"data: cannot decode JSON key into type '%s'", tkey)
+	}
+	return rkey, nil
+}
@@ -0,0 +1,138 @@
+package data
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type album struct {
+	Title string
+	Year  int
+}
+
+// upper is a key type that implements `encoding.TextMarshaler`.
+type upper struct {
+	s string
+}
+
+func (u upper) MarshalText() ([]byte, error) {
+	return []byte(strings.ToUpper(u.s)), nil
+}
+
+func (u *upper) UnmarshalText(text []byte) error {
+	u.s = strings.ToLower(string(text))
+	return nil
+}
+
+func TestOrdMapJSON(t *testing.T) {
+	strs := OrderedMap(new(string), new(string))
+	strs.Put("z", "last")
+	strs.Put("a", "first")
+	strs.Put("m", "middle")
+	testJSONRoundTrip(t, strs, OrderedMap(new(string), new(string)),
+		`{"z":"last","a":"first","m":"middle"}`)
+
+	ints := OrderedMap(new(int), new(int))
+	ints.Put(3, 9)
+	ints.Put(1, 1)
+	ints.Put(2, 4)
+	testJSONRoundTrip(t, ints, OrderedMap(new(int), new(int)),
+		`{"3":9,"1":1,"2":4}`)
+
+	albums := OrderedMap(new(string), new(album))
+	albums.Put("springsteen", album{"Born to Run", 1975})
+	albums.Put("seger", album{"Night Moves", 1976})
+	testJSONRoundTrip(t, albums, OrderedMap(new(string), new(album)),
+		`{"springsteen":{"Title":"Born to Run","Year":1975},`+
+			`"seger":{"Title":"Night Moves","Year":1976}}`)
+
+	texts := OrderedMap(new(upper), new(bool))
+	texts.Put(upper{"b"}, true)
+	texts.Put(upper{"a"}, false)
+	testJSONRoundTrip(t, texts, OrderedMap(new(upper), new(bool)),
+		`{"B":true,"A":false}`)
+
+	empty := OrderedMap(new(string), new(int))
+	testJSONRoundTrip(t, empty, OrderedMap(new(string), new(int)), `{}`)
+}
+
+func TestOrdMapJSONErrors(t *testing.T) {
+	if err := json.Unmarshal([]byte(`{"a":1}`), new(OrdMap)); err == nil {
+		t.Fatal("decoding into an uninstantiated OrdMap should fail")
+	}
+	if _, err := json.Marshal(new(OrdMap)); err == nil {
+		t.Fatal("encoding an uninstantiated OrdMap should fail")
+	}
+
+	omap := OrderedMap(new(int), new(int))
+	if err := json.Unmarshal([]byte(`{"a":1}`), omap); err == nil {
+		t.Fatal("decoding 'a' as an int key should fail")
+	}
+	if err := json.Unmarshal([]byte(`[1, 2]`), omap); err == nil {
+		t.Fatal("decoding an array into an OrdMap should fail")
+	}
+
+	floats := OrderedMap(new(float64), new(int))
+	floats.Put(1.5, 1)
+	if _, err := json.Marshal(floats); err == nil {
+		t.Fatal("encoding float keys should fail")
+	}
+}
+
+func TestOrdMapGob(t *testing.T) {
+	strs := OrderedMap(new(string), new(string))
+	strs.Put("z", "last")
+	strs.Put("a", "first")
+	testGobRoundTrip(t, strs, OrderedMap(new(string), new(string)))
+
+	ints := OrderedMap(new(int), new(int))
+	ints.Put(3, 9)
+	ints.Put(1, 1)
+	ints.Put(2, 4)
+	testGobRoundTrip(t, ints, OrderedMap(new(int), new(int)))
+
+	albums := OrderedMap(new(string), new(album))
+	albums.Put("springsteen", album{"Born to Run", 1975})
+	albums.Put("seger", album{"Night Moves", 1976})
+	testGobRoundTrip(t, albums, OrderedMap(new(string), new(album)))
+
+	buf := new(bytes.Buffer)
+	if err := gob.NewEncoder(buf).Encode(ints); err != nil {
+		t.Fatal(err)
+	}
+	if err := gob.NewDecoder(buf).Decode(new(OrdMap)); err == nil {
+		t.Fatal("decoding into an uninstantiated OrdMap should fail")
+	}
+	if _, err := new(OrdMap).GobEncode(); err == nil {
+		t.Fatal("encoding an uninstantiated OrdMap should fail")
+	}
+}
+
+func testJSONRoundTrip(t *testing.T, omap, decoded *OrdMap, expected string) {
+	bs, err := json.Marshal(omap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertDeep(t, string(bs), expected)
+
+	if err := json.Unmarshal(bs, decoded); err != nil {
+		t.Fatal(err)
+	}
+	assertDeep(t, decoded.Keys(), omap.Keys())
+	assertDeep(t, decoded.Values(), omap.Values())
+}
+
+func testGobRoundTrip(t *testing.T, omap, decoded *OrdMap) {
+	buf := new(bytes.Buffer)
+	if err := gob.NewEncoder(buf).Encode(omap); err != nil {
+		t.Fatal(err)
+	}
+	if err := gob.NewDecoder(buf).Decode(decoded); err != nil {
+		t.Fatal(err)
+	}
+	assertDeep(t, decoded.Keys(), omap.Keys())
+	assertDeep(t, decoded.Values(), omap.Values())
+}
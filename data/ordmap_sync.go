@@ -0,0 +1,259 @@
+package data
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/BurntSushi/ty"
+)
+
+// SyncOrdMap has a parametric type `SyncOrdMap<K, V>` and is an `OrdMap`
+// that is safe for concurrent use by multiple goroutines.
+//
+// Every operation acquires a read or write lock on the entire map. Compound
+// operations like `GetOrPut`, `Update` and `CompareAndSwap` are atomic.
+type SyncOrdMap struct {
+	mu sync.RWMutex
+	om *OrdMap
+
+	// The instance of `SyncOrdMap<K, V>`, with which every method checks its
+	// arguments, so that type errors name the `SyncOrdMap`.
+	inst *ty.Instance
+}
+
+// SyncOrderedMap returns a new instance of SyncOrdMap instantiated with the
+// key and value types given as nil pointers, just like `OrderedMap`.
+func SyncOrderedMap(ktype, vtype interface{}) *SyncOrdMap {
+	return newSyncOrdMap(OrderedMap(ktype, vtype))
+}
+
+// SyncOrderedMapOf is just like `SyncOrderedMap`, except the key and value
+// types are given as `reflect.Type` values. (See `OrderedMapOf`.)
+func SyncOrderedMapOf(ktype, vtype reflect.Type) *SyncOrdMap {
+	return newSyncOrdMap(OrderedMapOf(ktype, vtype))
+}
+
+// newSyncOrdMap returns a `SyncOrdMap` of `om`, with the same key and value
+// types.
+func newSyncOrdMap(om *OrdMap) *SyncOrdMap {
+	tk, _ := om.inst.Lookup(tyA)
+	tv, _ := om.inst.Lookup(tyB)
+	inst := ty.NewInstance("SyncOrdMap",
+		map[reflect.Type]reflect.Type{tyA: tk, tyB: tv})
+	return &SyncOrdMap{om: om, inst: inst}
+}
+
+// Exists has a parametric type:
+//
+//	func (sm *SyncOrdMap<K, V>) Exists(key K) bool
+//
+// Exists returns true if `key` is in the map `sm`.
+func (sm *SyncOrdMap) Exists(key interface{}) bool {
+	chk := sm.inst.Check(new(func(ty.A)), key)
+
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.om.exists(chk.Args[0])
+}
+
+// Put has a parametric type:
+//
+//	func (sm *SyncOrdMap<K, V>) Put(key K, val V)
+//
+// Put adds or overwrites `key` into the map `sm` with value `val`.
+func (sm *SyncOrdMap) Put(key, val interface{}) {
+	chk := sm.inst.Check(new(func(ty.A, ty.B)), key, val)
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.om.put(chk.Args[0], chk.Args[1])
+}
+
+// Get has a parametric type:
+//
+//	func (sm *SyncOrdMap<K, V>) Get(key K) V
+//
+// Get retrieves the value in the map `sm` corresponding to `key`, or the
+// zero value of `V` if `key` does not exist.
+func (sm *SyncOrdMap) Get(key interface{}) interface{} {
+	chk := sm.inst.Check(new(func(ty.A)), key)
+
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	rval, _ := sm.om.tryGet(chk.Args[0])
+	return rval.Interface()
+}
+
+// TryGet has a parametric type:
+//
+//	func (sm *SyncOrdMap<K, V>) TryGet(key K) (V, bool)
+//
+// TryGet retrieves the value in the map `sm` corresponding to `key` and
+// reports whether the value exists in the map or not.
+func (sm *SyncOrdMap) TryGet(key interface{}) (interface{}, bool) {
+	chk := sm.inst.Check(new(func(ty.A)), key)
+
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	rval, ok := sm.om.tryGet(chk.Args[0])
+	return rval.Interface(), ok
+}
+
+// Delete has a parametric type:
+//
+//	func (sm *SyncOrdMap<K, V>) Delete(key K)
+//
+// Delete removes `key` from the map `sm`.
+func (sm *SyncOrdMap) Delete(key interface{}) {
+	chk := sm.inst.Check(new(func(ty.A)), key)
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.om.delete(chk.Args[0])
+}
+
+// GetOrPut has a parametric type:
+//
+//	func (sm *SyncOrdMap<K, V>) GetOrPut(key K, val V) (V, bool)
+//
+// GetOrPut returns the existing value for `key` and true if `key` is in the
+// map `sm`. Otherwise, it adds `key` with value `val` and returns `val` and
+// false.
+func (sm *SyncOrdMap) GetOrPut(key, val interface{}) (interface{}, bool) {
+	chk := sm.inst.Check(new(func(ty.A, ty.B)), key, val)
+	rkey, rval := chk.Args[0], chk.Args[1]
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if cur := sm.om.m.MapIndex(rkey); cur.IsValid() {
+		return cur.Interface(), true
+	}
+	sm.om.put(rkey, rval)
+	return rval.Interface(), false
+}
+
+// Update has a parametric type:
+//
+//	func (sm *SyncOrdMap<K, V>) Update(key K, f func(V, bool) V) V
+//
+// Update atomically replaces the value of `key` in the map `sm` with the
+// value returned by `f`, and returns that value. `f` is given the current
+// value of `key` and whether it exists (if it doesn't, `f` is given the zero
+// value of `V`). If `key` is not in `sm`, it is added.
+//
+// `f` is called with `sm` locked, so it must not use `sm`.
+func (sm *SyncOrdMap) Update(key, f interface{}) interface{} {
+	chk := sm.inst.Check(new(func(ty.A, func(ty.B, bool) ty.B)), key, f)
+	rkey, rf := chk.Args[0], chk.Args[1]
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	rcur := sm.om.m.MapIndex(rkey)
+	rok := reflect.ValueOf(rcur.IsValid())
+	if !rcur.IsValid() {
+		rcur = sm.om.zeroValue()
+	}
+	rval := call(rf, rcur, rok)[0]
+	sm.om.put(rkey, rval)
+	return rval.Interface()
+}
+
+// compareAndSwapType is the parametric type checked by `CompareAndSwap`,
+// whose parameter `new` shadows the built-in function.
+var compareAndSwapType = new(func(ty.A, ty.B, ty.B))
+
+// CompareAndSwap has a parametric type:
+//
+//	func (sm *SyncOrdMap<K, V>) CompareAndSwap(key K, old, new V) bool
+//
+// CompareAndSwap replaces the value of `key` in the map `sm` with `new` if
+// and only if `key` exists and its value is equal to `old`. It reports
+// whether the value was replaced.
+//
+// The values are compared with `==`, so CompareAndSwap panics with a
+// `TypeError` if `V` is not a comparable type, or if `V` is an interface
+// type and `old` holds a value that is not comparable.
+func (sm *SyncOrdMap) CompareAndSwap(key, old, new interface{}) bool {
+	chk := sm.inst.Check(compareAndSwapType, key, old, new)
+	rkey, rold, rnew := chk.Args[0], chk.Args[1], chk.Args[2]
+	assertComparable(rold)
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	rcur := sm.om.m.MapIndex(rkey)
+	if !rcur.IsValid() || rcur.Interface() != rold.Interface() {
+		return false
+	}
+	sm.om.m.SetMapIndex(rkey, rnew)
+	return true
+}
+
+// assertComparable panics with a `TypeError` if `rv` cannot be compared
+// with `==`. For an interface, the type of the value it holds must be
+// comparable.
+func assertComparable(rv reflect.Value) {
+	t := rv.Type()
+	if t.Kind() == reflect.Interface && !rv.IsNil() {
+		t = rv.Elem().Type()
+	}
+	if !t.Comparable() {
+		panic(ty.TypeError(fmt.Sprintf(
+			"Values of type '%s' cannot be compared.", t)))
+	}
+}
+
+// Keys has a parametric type:
+//
+//	func (sm *SyncOrdMap<K, V>) Keys() []K
+//
+// Keys returns a copy of the list of keys in `sm` in the order they were
+// inserted.
+func (sm *SyncOrdMap) Keys() interface{} {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	rkeys := reflect.MakeSlice(sm.om.keys.Type(), sm.om.keys.Len(),
+		sm.om.keys.Len())
+	reflect.Copy(rkeys, sm.om.keys)
+	return rkeys.Interface()
+}
+
+// Values has a parametric type:
+//
+//	func (sm *SyncOrdMap<K, V>) Values() []V
+//
+// Values returns a shallow copy of the values in `sm` in the order that they
+// were inserted.
+func (sm *SyncOrdMap) Values() interface{} {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.om.Values()
+}
+
+// Len has a parametric type:
+//
+//	func (sm *SyncOrdMap<K, V>) Len() int
+//
+// Len returns the number of keys in the map `sm`.
+func (sm *SyncOrdMap) Len() int {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.om.Len()
+}
+
+// Snapshot has a parametric type:
+//
+//	func (sm *SyncOrdMap<K, V>) Snapshot() *OrdMap<K, V>
+//
+// Snapshot returns a shallow copy of `sm` as an `OrdMap`, taken while `sm`
+// is locked. The snapshot is consistent and is not affected by later
+// operations on `sm`, so it is the way to iterate over a `SyncOrdMap`:
+//
+//	sm.Snapshot().Each(func(key string, val int) {
+//		...
+//	})
+func (sm *SyncOrdMap) Snapshot() *OrdMap {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.om.clone()
+}
@@ -0,0 +1,119 @@
+package data
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/BurntSushi/ty"
+)
+
+func TestSyncOrdMap(t *testing.T) {
+	smap := SyncOrderedMap(new(string), new(int))
+	smap.Put("a", 1)
+	smap.Put("b", 2)
+	smap.Put("c", 3)
+	smap.Delete("b")
+
+	assertDeep(t, smap.Exists("a"), true)
+	assertDeep(t, smap.Exists("b"), false)
+	assertDeep(t, smap.Get("c"), 3)
+	assertDeep(t, smap.Len(), 2)
+	assertDeep(t, smap.Keys(), []string{"a", "c"})
+	assertDeep(t, smap.Values(), []int{1, 3})
+}
+
+func TestSyncOrdMapCompound(t *testing.T) {
+	smap := SyncOrderedMap(new(string), new(int))
+
+	val, loaded := smap.GetOrPut("a", 1)
+	assertDeep(t, val, 1)
+	assertDeep(t, loaded, false)
+	val, loaded = smap.GetOrPut("a", 2)
+	assertDeep(t, val, 1)
+	assertDeep(t, loaded, true)
+
+	incr := func(n int, ok bool) int {
+		if !ok {
+			return 100
+		}
+		return n + 1
+	}
+	assertDeep(t, smap.Update("a", incr), 2)
+	assertDeep(t, smap.Update("b", incr), 100)
+
+	assertDeep(t, smap.CompareAndSwap("a", 1, 10), false)
+	assertDeep(t, smap.CompareAndSwap("a", 2, 10), true)
+	assertDeep(t, smap.CompareAndSwap("z", 0, 10), false)
+	assertDeep(t, smap.Keys(), []string{"a", "b"})
+	assertDeep(t, smap.Values(), []int{10, 100})
+
+	// An untyped nil value is put as the zero value of `V`.
+	slices := SyncOrderedMap(new(string), new([]int))
+	val, _ = slices.GetOrPut("a", nil)
+	assertDeep(t, val, []int(nil))
+	assertDeep(t, val, slices.Get("a"))
+}
+
+func TestSyncOrdMapTypeError(t *testing.T) {
+	smap := SyncOrderedMap(new(string), new(int))
+	defer func() {
+		err, ok := recover().(ty.TypeError)
+		if !ok {
+			t.Fatal("putting a key of the wrong type should panic with a " +
+				"TypeError")
+		}
+		want := "func data.(*SyncOrdMap).Put(A, B) of SyncOrdMap<string, int>"
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("Expected a TypeError containing %q but got:\n%s",
+				want, err)
+		}
+	}()
+	smap.Put(1, 1)
+}
+
+func TestSyncOrdMapCompareAndSwapIncomparable(t *testing.T) {
+	smap := SyncOrderedMap(new(string), new([]int))
+	smap.Put("a", []int{1})
+	defer func() {
+		if _, ok := recover().(ty.TypeError); !ok {
+			t.Fatal("comparing slices should panic with a TypeError")
+		}
+	}()
+	smap.CompareAndSwap("a", []int{1}, []int{2})
+}
+
+func TestSyncOrdMapSnapshot(t *testing.T) {
+	smap := SyncOrderedMap(new(string), new(int))
+	smap.Put("a", 1)
+	smap.Put("b", 2)
+
+	snap := smap.Snapshot()
+	smap.Put("c", 3)
+	smap.Put("a", 10)
+	smap.Delete("b")
+
+	assertDeep(t, snap.Keys(), []string{"a", "b"})
+	assertDeep(t, snap.Values(), []int{1, 2})
+}
+
+func TestSyncOrdMapConcurrent(t *testing.T) {
+	smap := SyncOrderedMap(new(int), new(int))
+	incr := func(n int, ok bool) int { return n + 1 }
+
+	wg := new(sync.WaitGroup)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				smap.Update(j%10, incr)
+				smap.Snapshot().Each(func(k, v int) {})
+			}
+		}()
+	}
+	wg.Wait()
+
+	assertDeep(t, smap.Values(), []int{100, 100, 100, 100, 100,
+		100, 100, 100, 100, 100})
+}
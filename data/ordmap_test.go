@@ -3,7 +3,10 @@ package data
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/BurntSushi/ty"
 )
 
 var pf = fmt.Printf
@@ -23,6 +26,208 @@ func TestOrdMap(t *testing.T) {
 	assertDeep(t, omap.Values(), []int{25, 20, 24, 25})
 }
 
+func TestOrdMapEach(t *testing.T) {
+	omap := OrderedMap(new(string), new(int))
+	omap.Put("a", 1)
+	omap.Put("b", 2)
+	omap.Put("c", 3)
+
+	keys, vals := make([]string, 0), make([]int, 0)
+	omap.Each(func(k string, v int) {
+		keys = append(keys, k)
+		vals = append(vals, v)
+	})
+	assertDeep(t, keys, []string{"a", "b", "c"})
+	assertDeep(t, vals, []int{1, 2, 3})
+
+	keys = make([]string, 0)
+	omap.EachWhile(func(k string, v int) bool {
+		keys = append(keys, k)
+		return v < 2
+	})
+	assertDeep(t, keys, []string{"a", "b"})
+
+	keys = make([]string, 0)
+	omap.EachReverse(func(k string, v int) { keys = append(keys, k) })
+	assertDeep(t, keys, []string{"c", "b", "a"})
+}
+
+func TestOrdMapAll(t *testing.T) {
+	omap := OrderedMap(new(string), new(int))
+	omap.Put("a", 1)
+	omap.Put("b", 2)
+	omap.Put("c", 3)
+
+	keys := make([]string, 0)
+	omap.All()(func(k, v interface{}) bool {
+		keys = append(keys, k.(string))
+		return k != "b"
+	})
+	assertDeep(t, keys, []string{"a", "b"})
+
+	keys = make([]string, 0)
+	omap.Backward()(func(k, v interface{}) bool {
+		keys = append(keys, k.(string))
+		return true
+	})
+	assertDeep(t, keys, []string{"c", "b", "a"})
+}
+
+func TestOrdMapEachModify(t *testing.T) {
+	omap := OrderedMap(new(string), new(int))
+	omap.Put("a", 1)
+	omap.Put("b", 2)
+	omap.Put("c", 3)
+	omap.Put("d", 4)
+
+	keys, vals := make([]string, 0), make([]int, 0)
+	omap.Each(func(k string, v int) {
+		keys = append(keys, k)
+		vals = append(vals, v)
+		if k == "a" {
+			omap.Delete("c")
+			omap.Put("b", 20)
+			omap.Put("e", 5)
+		}
+	})
+	assertDeep(t, keys, []string{"a", "b", "d"})
+	assertDeep(t, vals, []int{1, 20, 4})
+	assertDeep(t, omap.Keys(), []string{"a", "b", "d", "e"})
+}
+
+func TestOrdMapDelete(t *testing.T) {
+	omap := OrderedMap(new(string), new(int))
+	omap.Put("a", 1)
+	omap.Put("b", 2)
+	omap.Put("c", 3)
+
+	omap.Delete("a")
+	assertDeep(t, omap.Keys(), []string{"b", "c"})
+	assertDeep(t, omap.Exists("a"), false)
+
+	omap.Put("a", 1)
+	assertDeep(t, omap.Keys(), []string{"b", "c", "a"})
+}
+
+func TestOrderedMapOf(t *testing.T) {
+	omap := OrderedMapOf(reflect.TypeOf(""), reflect.TypeOf([]int{}))
+	omap.Put("a", []int{1, 2})
+	assertDeep(t, omap.Get("a"), []int{1, 2})
+	assertDeep(t, omap.Keys(), []string{"a"})
+
+	defer func() {
+		if _, ok := recover().(ty.TypeError); !ok {
+			t.Fatal("a slice key type should panic with a TypeError")
+		}
+	}()
+	OrderedMapOf(reflect.TypeOf([]int{}), reflect.TypeOf(""))
+}
+
+func TestOrderedMapOfNil(t *testing.T) {
+	defer func() {
+		if _, ok := recover().(ty.TypeError); !ok {
+			t.Fatal("a nil key type should panic with a TypeError")
+		}
+	}()
+	OrderedMapOf(nil, reflect.TypeOf(""))
+}
+
+func TestOrdMapCloneEqual(t *testing.T) {
+	omap := OrderedMap(new(string), new([]int))
+	omap.Put("a", []int{1})
+	omap.Put("b", []int{2})
+
+	clone := omap.Clone()
+	assertDeep(t, clone.Equal(omap), true)
+
+	clone.Put("c", []int{3})
+	assertDeep(t, clone.Equal(omap), false)
+	assertDeep(t, omap.Keys(), []string{"a", "b"})
+
+	clone.Delete("c")
+	clone.Put("a", []int{10})
+	assertDeep(t, clone.Equal(omap), false)
+
+	reordered := OrderedMap(new(string), new([]int))
+	reordered.Put("b", []int{2})
+	reordered.Put("a", []int{1})
+	assertDeep(t, reordered.Equal(omap), false)
+
+	other := OrderedMap(new(string), new([]string))
+	assertDeep(t, other.Equal(OrderedMap(new(string), new([]int))), false)
+	assertDeep(t, omap.Equal(nil), false)
+}
+
+func TestOrdMapMerge(t *testing.T) {
+	omap := OrderedMap(new(string), new(int))
+	omap.Put("a", 1)
+	omap.Put("b", 2)
+
+	other := OrderedMap(new(string), new(int))
+	other.Put("c", 30)
+	other.Put("b", 20)
+
+	sum := func(k string, v1, v2 int) int { return v1 + v2 }
+	merged := omap.Clone()
+	merged.Merge(other, sum)
+	assertDeep(t, merged.Keys(), []string{"a", "b", "c"})
+	assertDeep(t, merged.Values(), []int{1, 22, 30})
+
+	merged = omap.Clone()
+	merged.Merge(other, nil)
+	assertDeep(t, merged.Values(), []int{1, 20, 30})
+
+	merged = omap.Clone()
+	merged.Merge(nil, sum)
+	assertDeep(t, merged.Values(), []int{1, 2})
+
+	defer func() {
+		if _, ok := recover().(ty.TypeError); !ok {
+			t.Fatal("merging different types should panic with a TypeError")
+		}
+	}()
+	omap.Merge(OrderedMap(new(string), new(string)), nil)
+}
+
+func TestOrdMapTypeError(t *testing.T) {
+	omap := OrderedMap(new(string), new(int))
+	defer func() {
+		err, ok := recover().(ty.TypeError)
+		if !ok {
+			t.Fatal("putting a key of the wrong type should panic with a " +
+				"TypeError")
+		}
+		want := "func data.(*OrdMap).Put(A, B) of OrdMap<string, int>"
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("Expected a TypeError containing %q but got:\n%s",
+				want, err)
+		}
+	}()
+	omap.Put(1, 1)
+}
+
+func BenchmarkOrdMapPutGet(b *testing.B) {
+	omap := OrderedMap(new(string), new(int))
+	for i := 0; i < b.N; i++ {
+		omap.Put("a", i)
+		omap.Get("a")
+	}
+}
+
+func TestOrdMapNilFunction(t *testing.T) {
+	omap := OrderedMap(new(string), new(int))
+	omap.Each(nil)
+	omap.Put("a", 1)
+	defer func() {
+		err, ok := recover().(ty.TypeError)
+		if !ok || !strings.Contains(err.Error(), "func(string, int)") {
+			t.Fatalf("Calling Each with nil should panic with a TypeError "+
+				"naming the function type, but got %v", err)
+		}
+	}()
+	omap.Each(nil)
+}
+
 func ExampleOrderedMap() {
 	omap := OrderedMap(new(string), new([]string))
 
@@ -38,7 +243,7 @@ func ExampleOrderedMap() {
 	}
 
 	omap.Delete("J. Geils Band")
-	fmt.Println("\nDeleted 'J. Geils Band'...\n")
+	fmt.Print("\nDeleted 'J. Geils Band'...\n\n")
 
 	for _, key := range omap.Keys().([]string) {
 		fmt.Printf("%s: %v\n", key, omap.Get(key))
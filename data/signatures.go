@@ -0,0 +1,52 @@
+package data
+
+import (
+	"github.com/BurntSushi/ty"
+)
+
+// The parametric types of the functions and methods in this package are
+// registered with `ty.RegisterSignature`, so that tools can list them and
+// type errors can print them. Methods are named like `(*OrdMap).Put` and
+// their types omit the receiver, whose parametric type `OrdMap<K, V>` binds
+// `K` and `V`, written as `A` and `B`.
+func init() {
+	for name, sig := range signatures {
+		ty.RegisterSignature("data."+name, sig)
+	}
+}
+
+var signatures = map[string]interface{}{
+	"OrderedMap":     new(func(*ty.A, *ty.B) *OrdMap),
+	"SyncOrderedMap": new(func(*ty.A, *ty.B) *SyncOrdMap),
+
+	"(*OrdMap).Exists":      new(func(ty.A) bool),
+	"(*OrdMap).Put":         new(func(ty.A, ty.B)),
+	"(*OrdMap).Get":         new(func(ty.A) ty.B),
+	"(*OrdMap).TryGet":      new(func(ty.A) (ty.B, bool)),
+	"(*OrdMap).Delete":      new(func(ty.A)),
+	"(*OrdMap).Keys":        new(func() []ty.A),
+	"(*OrdMap).Values":      new(func() []ty.B),
+	"(*OrdMap).Each":        new(func(func(ty.A, ty.B))),
+	"(*OrdMap).EachWhile":   new(func(func(ty.A, ty.B) bool)),
+	"(*OrdMap).EachReverse": new(func(func(ty.A, ty.B))),
+	"(*OrdMap).All":         new(func() func(func(ty.A, ty.B) bool)),
+	"(*OrdMap).Backward":    new(func() func(func(ty.A, ty.B) bool)),
+	"(*OrdMap).Len":         new(func() int),
+	"(*OrdMap).Clone":       new(func() *OrdMap),
+	"(*OrdMap).Equal":       new(func(*OrdMap) bool),
+	"(*OrdMap).Merge": new(
+		func(*OrdMap, func(ty.A, ty.B, ty.B) ty.B)),
+
+	"(*SyncOrdMap).Exists":         new(func(ty.A) bool),
+	"(*SyncOrdMap).Put":            new(func(ty.A, ty.B)),
+	"(*SyncOrdMap).Get":            new(func(ty.A) ty.B),
+	"(*SyncOrdMap).TryGet":         new(func(ty.A) (ty.B, bool)),
+	"(*SyncOrdMap).Delete":         new(func(ty.A)),
+	"(*SyncOrdMap).GetOrPut":       new(func(ty.A, ty.B) (ty.B, bool)),
+	"(*SyncOrdMap).Update":         new(func(ty.A, func(ty.B, bool) ty.B) ty.B),
+	"(*SyncOrdMap).CompareAndSwap": new(func(ty.A, ty.B, ty.B) bool),
+	"(*SyncOrdMap).Keys":           new(func() []ty.A),
+	"(*SyncOrdMap).Values":         new(func() []ty.B),
+	"(*SyncOrdMap).Len":            new(func() int),
+	"(*SyncOrdMap).Snapshot":       new(func() *OrdMap),
+}
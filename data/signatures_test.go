@@ -0,0 +1,65 @@
+package data
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/ty"
+)
+
+func TestSignatures(t *testing.T) {
+	funcs := map[string]interface{}{
+		"OrderedMap":     OrderedMap,
+		"SyncOrderedMap": SyncOrderedMap,
+	}
+	for name := range signatures {
+		sig, ok := ty.LookupSignature("data." + name)
+		if !ok {
+			t.Errorf("%s is not registered.", name)
+			continue
+		}
+
+		// The types of methods include their receivers.
+		var typ reflect.Type
+		receivers := 0
+		if strings.HasPrefix(name, "(*") {
+			i := strings.Index(name, ")")
+			recv := map[string]interface{}{
+				"OrdMap":     new(OrdMap),
+				"SyncOrdMap": new(SyncOrdMap),
+			}[name[2:i]]
+			m, ok := reflect.TypeOf(recv).MethodByName(name[i+2:])
+			if !ok {
+				t.Errorf("%s is not a method.", name)
+				continue
+			}
+			typ, receivers = m.Type, 1
+		} else {
+			typ = reflect.TypeOf(funcs[name])
+		}
+		if typ.NumIn()-receivers != len(sig.Params()) ||
+			typ.NumOut() != len(sig.Results()) {
+			t.Errorf("%s does not match the arity of '%s'.", sig, typ)
+		}
+	}
+
+	// Every method is registered, except those of the encoding interfaces,
+	// which are not parametric.
+	encoding := map[string]bool{
+		"MarshalJSON": true, "UnmarshalJSON": true,
+		"GobEncode": true, "GobDecode": true,
+	}
+	for recv, v := range map[string]interface{}{
+		"OrdMap":     new(OrdMap),
+		"SyncOrdMap": new(SyncOrdMap),
+	} {
+		typ := reflect.TypeOf(v)
+		for i := 0; i < typ.NumMethod(); i++ {
+			name := "(*" + recv + ")." + typ.Method(i).Name
+			if _, ok := signatures[name]; !ok && !encoding[typ.Method(i).Name] {
+				t.Errorf("%s is not registered.", name)
+			}
+		}
+	}
+}
@@ -0,0 +1,8 @@
+//go:build tydebug
+// +build tydebug
+
+package ty
+
+// debug is true when the `tydebug` build tag is set, which turns on the
+// checks of `Typed.VerifyReturns` and `Checked`.
+const debug = true
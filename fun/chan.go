@@ -8,7 +8,7 @@ import (
 
 // AsyncChan has a parametric type:
 //
-//	func AsyncChan(chan A) (send chan<- A, recv <-chan A)
+//	func AsyncChan(*chan A) (send chan<- A, recv <-chan A)
 //
 // AsyncChan provides a channel abstraction without a fixed size buffer.
 // The input should be a pointer to a channel that has a type without a
@@ -82,3 +82,15 @@ func AsyncChan(baseChan interface{}) (send, recv interface{}) {
 	trDir := reflect.ChanOf(reflect.RecvDir, trecv.Elem())
 	return rsend.Convert(tsDir).Interface(), rrecv.Convert(trDir).Interface()
 }
+
+// recvChan converts `ch` to a receive-only channel if it is a bidirectional
+// channel, so that it can be given to a function whose parametric type
+// expects a `<-chan A`. Any other value is returned unchanged.
+func recvChan(ch interface{}) interface{} {
+	rch := reflect.ValueOf(ch)
+	if rch.Kind() != reflect.Chan || rch.Type().ChanDir() != reflect.BothDir {
+		return ch
+	}
+	return rch.Convert(reflect.ChanOf(reflect.RecvDir, rch.Type().Elem())).
+		Interface()
+}
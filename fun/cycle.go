@@ -43,5 +43,5 @@ func CycleMap(f, xs interface{}, n int) interface{} {
 			vys.Index(t*xsLen + i).Set(vy)
 		}
 	}
-	return vys.Interface()
+	return verified(chk, vys.Interface())
 }
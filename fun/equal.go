@@ -0,0 +1,448 @@
+package fun
+
+import (
+	"encoding/binary"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"unsafe"
+
+	"github.com/BurntSushi/ty"
+)
+
+// Equal has a parametric type:
+//
+//	func Equal(a A, b A) bool
+//
+// Equal returns true if `a` and `b` are deeply equal. Unlike Go's `==`,
+// Equal is defined for every type, which includes slices, maps and
+// functions. It is equivalent to `Compare(a, b) == 0`.
+func Equal(a, b interface{}) bool {
+	chk := ty.Check(
+		new(func(ty.A, ty.A) bool),
+		a, b)
+	return deepCompare(chk.Args[0], chk.Args[1]) == 0
+}
+
+// Compare has a parametric type:
+//
+//	func Compare(a A, b A) int
+//
+// Compare returns -1 if `a` is less than `b`, 0 if they are deeply equal
+// and 1 if `a` is greater than `b`. The order is total and defined for
+// every Go type:
+//
+// Numbers and strings have their natural order, where NaN is equal to
+// itself and less than every other number, and `false` is less than `true`.
+// Complex numbers are ordered by their real part and then their imaginary
+// part.
+//
+// Arrays, slices and structs are ordered lexicographically by their
+// elements or fields. A nil slice is equal to an empty slice.
+//
+// Maps are ordered as if they were lists of key and value pairs sorted by
+// key. A nil map is equal to an empty map.
+//
+// Pointers and interfaces are ordered by the values they point to, where
+// nil is less than any other value. Interfaces with different dynamic types
+// are ordered by the names of those types. Cyclic data is handled in the
+// same way as `reflect.DeepEqual`.
+//
+// Channels, functions and unsafe pointers are ordered by their address,
+// which is only meaningful for testing equality.
+func Compare(a, b interface{}) int {
+	chk := ty.Check(
+		new(func(ty.A, ty.A) int),
+		a, b)
+	return deepCompare(chk.Args[0], chk.Args[1])
+}
+
+// DeepHash has a parametric type:
+//
+//	func DeepHash(x A) uint64
+//
+// DeepHash returns a hash of `x` that is consistent with `Equal`. That is,
+// if `Equal(a, b)` then `DeepHash(a) == DeepHash(b)`. Like `Equal`, it is
+// defined for every Go type.
+//
+// Hashes are not stable across different runs of a program.
+func DeepHash(x interface{}) uint64 {
+	chk := ty.Check(
+		new(func(ty.A) uint64),
+		x)
+	return deepHash(chk.Args[0])
+}
+
+// visit identifies a pair of references that are being compared, so that
+// cyclic data does not recurse forever.
+type visit struct {
+	a, b unsafe.Pointer
+	typ  reflect.Type
+}
+
+func deepCompare(va, vb reflect.Value) int {
+	return new(comparer).compare(va, vb)
+}
+
+type comparer struct {
+	visited map[visit]bool
+}
+
+// seen reports whether the pair of references `va` and `vb` is already
+// being compared, and marks it as being compared if not.
+func (c *comparer) seen(va, vb reflect.Value) bool {
+	v := visit{va.UnsafePointer(), vb.UnsafePointer(), va.Type()}
+	if c.visited[v] {
+		return true
+	}
+	if c.visited == nil {
+		c.visited = make(map[visit]bool)
+	}
+	c.visited[v] = true
+	return false
+}
+
+func (c *comparer) compare(va, vb reflect.Value) int {
+	switch va.Kind() {
+	case reflect.Bool:
+		return compareBool(va.Bool(), vb.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		return compareInt(va.Int(), vb.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		return compareUint(va.Uint(), vb.Uint())
+	case reflect.Float32, reflect.Float64:
+		return compareFloat(va.Float(), vb.Float())
+	case reflect.Complex64, reflect.Complex128:
+		ca, cb := va.Complex(), vb.Complex()
+		if cmp := compareFloat(real(ca), real(cb)); cmp != 0 {
+			return cmp
+		}
+		return compareFloat(imag(ca), imag(cb))
+	case reflect.String:
+		return strings.Compare(va.String(), vb.String())
+	case reflect.Array:
+		return c.compareList(va, vb)
+	case reflect.Slice:
+		if va.Len() > 0 && vb.Len() > 0 && c.seen(va, vb) {
+			return 0
+		}
+		return c.compareList(va, vb)
+	case reflect.Struct:
+		for i := 0; i < va.NumField(); i++ {
+			if cmp := c.compare(va.Field(i), vb.Field(i)); cmp != 0 {
+				return cmp
+			}
+		}
+		return 0
+	case reflect.Map:
+		if va.Len() > 0 && vb.Len() > 0 && c.seen(va, vb) {
+			return 0
+		}
+		return c.compareMap(va, vb)
+	case reflect.Ptr:
+		if va.IsNil() || vb.IsNil() {
+			return compareBool(!va.IsNil(), !vb.IsNil())
+		}
+		if va.Pointer() == vb.Pointer() || c.seen(va, vb) {
+			return 0
+		}
+		return c.compare(va.Elem(), vb.Elem())
+	case reflect.Interface:
+		if va.IsNil() || vb.IsNil() {
+			return compareBool(!va.IsNil(), !vb.IsNil())
+		}
+		ea, eb := va.Elem(), vb.Elem()
+		if ea.Type() != eb.Type() {
+			return strings.Compare(typeName(ea.Type()), typeName(eb.Type()))
+		}
+		return c.compare(ea, eb)
+	}
+
+	// Channels, functions and unsafe pointers.
+	return compareUint(uint64(va.Pointer()), uint64(vb.Pointer()))
+}
+
+func (c *comparer) compareList(va, vb reflect.Value) int {
+	aLen, bLen := va.Len(), vb.Len()
+	for i := 0; i < aLen && i < bLen; i++ {
+		if cmp := c.compare(va.Index(i), vb.Index(i)); cmp != 0 {
+			return cmp
+		}
+	}
+	return compareInt(int64(aLen), int64(bLen))
+}
+
+func (c *comparer) compareMap(va, vb reflect.Value) int {
+	aents, bents := sortedEntries(va), sortedEntries(vb)
+	for i := 0; i < len(aents) && i < len(bents); i++ {
+		if cmp := c.compare(aents[i].key, bents[i].key); cmp != 0 {
+			return cmp
+		}
+		if cmp := c.compare(aents[i].val, bents[i].val); cmp != 0 {
+			return cmp
+		}
+	}
+	return compareInt(int64(len(aents)), int64(len(bents)))
+}
+
+// sortedEntries returns the keys and values of the map `vm` in ascending
+// order of its keys, and then of its values for keys that compare equal
+// (e.g., distinct pointers to equal values).
+//
+// Each pair of entries is compared from scratch, since the pairs of
+// references recorded while comparing one pair must not affect another.
+// Otherwise, comparing the same pair twice could give different results.
+func sortedEntries(vm reflect.Value) []deepEntry {
+	entries := make([]deepEntry, 0, vm.Len())
+	iter := vm.MapRange()
+	for iter.Next() {
+		entries = append(entries, deepEntry{iter.Key(), iter.Value()})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		ei, ej := entries[i], entries[j]
+		if cmp := deepCompare(ei.key, ej.key); cmp != 0 {
+			return cmp < 0
+		}
+		return deepCompare(ei.val, ej.val) < 0
+	})
+	return entries
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case !a:
+		return -1
+	}
+	return 1
+}
+
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case math.IsNaN(a) || math.IsNaN(b):
+		return compareBool(!math.IsNaN(a), !math.IsNaN(b))
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// typeName returns a name for `t` that distinguishes it from types with the
+// same name in other packages.
+func typeName(t reflect.Type) string {
+	if t.PkgPath() == "" {
+		return t.String()
+	}
+	return t.PkgPath() + "." + t.Name()
+}
+
+func deepHash(v reflect.Value) uint64 {
+	h := newHasher(make(map[unsafe.Pointer]bool))
+	h.hash(v)
+	return h.sum
+}
+
+// hasher computes a hash of a value using FNV-1a. Maps are hashed
+// independently of their order by summing the hashes of their entries.
+type hasher struct {
+	sum uint64
+	buf [8]byte
+
+	// The references currently being hashed, so that cyclic data does not
+	// recurse forever.
+	visiting map[unsafe.Pointer]bool
+}
+
+func newHasher(visiting map[unsafe.Pointer]bool) *hasher {
+	const offset64 = 14695981039346656037
+	return &hasher{sum: offset64, visiting: visiting}
+}
+
+func (h *hasher) write(bs []byte) {
+	const prime64 = 1099511628211
+	for _, b := range bs {
+		h.sum ^= uint64(b)
+		h.sum *= prime64
+	}
+}
+
+func (h *hasher) writeUint(n uint64) {
+	binary.LittleEndian.PutUint64(h.buf[:], n)
+	h.write(h.buf[:])
+}
+
+func (h *hasher) writeFloat(f float64) {
+	switch {
+	case math.IsNaN(f):
+		f = math.NaN()
+	case f == 0:
+		// Make sure that -0 and +0 hash to the same value.
+		f = 0
+	}
+	h.writeUint(math.Float64bits(f))
+}
+
+// enter reports whether the reference `v` can be hashed without recursing
+// forever. If it returns true, `leave` must be called once `v` is hashed.
+func (h *hasher) enter(v reflect.Value) bool {
+	p := v.UnsafePointer()
+	if h.visiting[p] {
+		return false
+	}
+	h.visiting[p] = true
+	return true
+}
+
+func (h *hasher) leave(v reflect.Value) {
+	delete(h.visiting, v.UnsafePointer())
+}
+
+func (h *hasher) hash(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			h.writeUint(1)
+		} else {
+			h.writeUint(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		h.writeUint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		h.writeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		h.writeFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		h.writeFloat(real(v.Complex()))
+		h.writeFloat(imag(v.Complex()))
+	case reflect.String:
+		h.writeUint(uint64(v.Len()))
+		h.write([]byte(v.String()))
+	case reflect.Array:
+		h.hashList(v)
+	case reflect.Slice:
+		if v.Len() > 0 {
+			if !h.enter(v) {
+				return
+			}
+			defer h.leave(v)
+		}
+		h.hashList(v)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			h.hash(v.Field(i))
+		}
+	case reflect.Map:
+		if v.Len() > 0 {
+			if !h.enter(v) {
+				return
+			}
+			defer h.leave(v)
+		}
+		var sum uint64
+		iter := v.MapRange()
+		for iter.Next() {
+			entry := newHasher(h.visiting)
+			entry.hash(iter.Key())
+			entry.hash(iter.Value())
+			sum += entry.sum
+		}
+		h.writeUint(uint64(v.Len()))
+		h.writeUint(sum)
+	case reflect.Ptr:
+		if v.IsNil() {
+			h.writeUint(0)
+			return
+		}
+		h.writeUint(1)
+		if !h.enter(v) {
+			return
+		}
+		defer h.leave(v)
+		h.hash(v.Elem())
+	case reflect.Interface:
+		if v.IsNil() {
+			h.writeUint(0)
+			return
+		}
+		h.writeUint(1)
+		h.write([]byte(typeName(v.Elem().Type())))
+		h.hash(v.Elem())
+	default:
+		// Channels, functions and unsafe pointers.
+		h.writeUint(uint64(v.Pointer()))
+	}
+}
+
+func (h *hasher) hashList(v reflect.Value) {
+	vLen := v.Len()
+	h.writeUint(uint64(vLen))
+	for i := 0; i < vLen; i++ {
+		h.hash(v.Index(i))
+	}
+}
+
+// deepMap is a hash map whose keys may have any type, including types that
+// cannot be compared with `==`. Keys are compared with `Equal` and hashed
+// with `DeepHash`.
+type deepMap struct {
+	buckets map[uint64][]deepEntry
+}
+
+type deepEntry struct {
+	key, val reflect.Value
+}
+
+func newDeepMap() *deepMap {
+	return &deepMap{buckets: make(map[uint64][]deepEntry)}
+}
+
+// get returns the value associated with `key` and whether it exists.
+func (dm *deepMap) get(key reflect.Value) (reflect.Value, bool) {
+	for _, entry := range dm.buckets[deepHash(key)] {
+		if deepCompare(entry.key, key) == 0 {
+			return entry.val, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// put associates `key` with `val`, replacing any existing value.
+func (dm *deepMap) put(key, val reflect.Value) {
+	h := deepHash(key)
+	bucket := dm.buckets[h]
+	for i, entry := range bucket {
+		if deepCompare(entry.key, key) == 0 {
+			bucket[i].val = val
+			return
+		}
+	}
+	dm.buckets[h] = append(bucket, deepEntry{key, val})
+}
@@ -0,0 +1,117 @@
+package fun
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+type equalTest struct {
+	a, b interface{}
+	cmp  int
+}
+
+type node struct {
+	val  int
+	next *node
+}
+
+func TestCompare(t *testing.T) {
+	one, alsoOne := 1, 1
+	f := func() {}
+	cyclic1 := &node{val: 1}
+	cyclic1.next = cyclic1
+	cyclic2 := &node{val: 1}
+	cyclic2.next = cyclic2
+
+	tests := []equalTest{
+		{1, 2, -1},
+		{2, 2, 0},
+		{uint8(3), uint8(2), 1},
+		{"a", "b", -1},
+		{false, true, -1},
+		{1.5, math.NaN(), 1},
+		{math.NaN(), math.NaN(), 0},
+		{complex(1, 2), complex(1, 3), -1},
+		{[]int{1, 2}, []int{1, 2}, 0},
+		{[]int{1, 2}, []int{1, 2, 0}, -1},
+		{[]int{1, 3}, []int{1, 2, 0}, 1},
+		{[]int(nil), []int{}, 0},
+		{[2]string{"a", "b"}, [2]string{"a", "c"}, -1},
+		{map[string]int{"a": 1, "b": 2}, map[string]int{"b": 2, "a": 1}, 0},
+		{map[string]int{"a": 1, "b": 2}, map[string]int{"a": 1, "c": 0}, -1},
+		{map[string]int{"a": 1}, map[string]int{"a": 2}, -1},
+		{map[string]int(nil), map[string]int{}, 0},
+		{&one, &alsoOne, 0},
+		{(*int)(nil), &one, -1},
+		{node{1, nil}, node{1, &node{}}, -1},
+		{cyclic1, cyclic2, 0},
+		{[]interface{}{1, "a"}, []interface{}{1, "a"}, 0},
+		{[]interface{}{nil}, []interface{}{1}, -1},
+		{f, f, 0},
+		{func() {}, (func())(nil), 1},
+	}
+	for _, test := range tests {
+		if cmp := Compare(test.a, test.b); cmp != test.cmp {
+			t.Fatalf("Compare(%#v, %#v) == %d, but expected %d",
+				test.a, test.b, cmp, test.cmp)
+		}
+		if cmp := Compare(test.b, test.a); cmp != -test.cmp {
+			t.Fatalf("Compare(%#v, %#v) == %d, but expected %d",
+				test.b, test.a, cmp, -test.cmp)
+		}
+		if Equal(test.a, test.b) != (test.cmp == 0) {
+			t.Fatalf("Equal(%#v, %#v) != %v", test.a, test.b, test.cmp == 0)
+		}
+		if test.cmp == 0 && DeepHash(test.a) != DeepHash(test.b) {
+			t.Fatalf("DeepHash(%#v) != DeepHash(%#v)", test.a, test.b)
+		}
+	}
+}
+
+func TestCompareMapKeys(t *testing.T) {
+	// Keys that point to equal values force the sort of the entries to
+	// compare the same pairs of pointers more than once.
+	ptrs1, ptrs2 := make(map[*int]int), make(map[*int]int)
+	ifaces1, ifaces2 := make(map[interface{}]int), make(map[interface{}]int)
+	for i := 0; i < 20; i++ {
+		p := new(int)
+		*p = i % 3
+		ptrs1[p], ptrs2[p] = i, i
+		ifaces1[p], ifaces2[p] = i, i
+		ifaces1[i], ifaces2[i] = i, i
+		ifaces1[fmt.Sprint(i)], ifaces2[fmt.Sprint(i)] = i, i
+	}
+
+	for _, test := range []struct{ a, b interface{} }{
+		{ptrs1, ptrs2},
+		{ifaces1, ifaces2},
+	} {
+		if !Equal(test.a, test.b) {
+			t.Fatalf("Expected equal maps:\n%v\n%v", test.a, test.b)
+		}
+		if cmp := Compare(test.a, test.b); cmp != 0 {
+			t.Fatalf("Compare of equal maps == %d, but expected 0", cmp)
+		}
+	}
+
+	for p := range ptrs2 {
+		ptrs2[p]++
+	}
+	if Equal(ptrs1, ptrs2) {
+		t.Fatal("Expected maps with different values to differ")
+	}
+}
+
+func TestDeepHash(t *testing.T) {
+	p1, p2 := &node{val: 1}, &node{val: 1}
+	assertDeep(t, DeepHash([]*node{p1, p1}), DeepHash([]*node{p1, p2}))
+	assertDeep(t, DeepHash(0.0), DeepHash(math.Copysign(0, -1)))
+
+	if DeepHash([]int{1, 2}) == DeepHash([]int{2, 1}) {
+		t.Fatal("DeepHash should depend on the order of slices")
+	}
+	if DeepHash([]string{"ab", "c"}) == DeepHash([]string{"a", "bc"}) {
+		t.Fatal("DeepHash should depend on the boundaries of strings")
+	}
+}
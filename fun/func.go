@@ -15,7 +15,7 @@ import (
 // `!=` are fully defined (this rules out functions, maps and slices).
 func Memo(f interface{}) interface{} {
 	chk := ty.Check(
-		new(func(func(ty.A) ty.B)),
+		new(func(func(ty.A) ty.B) func(ty.A) ty.B),
 		f)
 	vf := chk.Args[0]
 
@@ -31,5 +31,35 @@ func Memo(f interface{}) interface{} {
 		saved[val] = ret
 		return []reflect.Value{ret}
 	}
-	return reflect.MakeFunc(vf.Type(), memo).Interface()
+	return verified(chk, reflect.MakeFunc(vf.Type(), memo).Interface())
+}
+
+// MemoBy has a parametric type:
+//
+//	func MemoBy(f func(A) B) func(A) B
+//
+// MemoBy is just like `Memo`, except `A` may be any type, including types
+// that cannot be compared with `==` such as slices, maps and functions.
+// Arguments are compared with `Equal` and hashed with `DeepHash`.
+//
+// Arguments are not copied, so an argument must not be modified after it is
+// passed to the memoized function.
+func MemoBy(f interface{}) interface{} {
+	chk := ty.Check(
+		new(func(func(ty.A) ty.B) func(ty.A) ty.B),
+		f)
+	vf := chk.Args[0]
+
+	saved := newDeepMap()
+	memo := func(in []reflect.Value) []reflect.Value {
+		ret, ok := saved.get(in[0])
+		if ok {
+			return []reflect.Value{ret}
+		}
+
+		ret = call1(vf, in[0])
+		saved.put(in[0], ret)
+		return []reflect.Value{ret}
+	}
+	return verified(chk, reflect.MakeFunc(vf.Type(), memo).Interface())
 }
@@ -0,0 +1,356 @@
+package fun
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/BurntSushi/ty"
+)
+
+// Compose has a parametric type:
+//
+//	func Compose(f func(B) C, g func(A) B) func(A) C
+//
+// Compose returns the composition of `f` and `g`, which is a function that
+// applies `g` to its argument and then applies `f` to the result.
+func Compose(f, g interface{}) interface{} {
+	chk := ty.Check(
+		new(func(func(ty.B) ty.C, func(ty.A) ty.B) func(ty.A) ty.C),
+		f, g)
+	vf, vg, tfg := chk.Args[0], chk.Args[1], chk.Returns[0]
+
+	compose := func(in []reflect.Value) []reflect.Value {
+		return []reflect.Value{call1(vf, call1(vg, in[0]))}
+	}
+	return verified(chk, reflect.MakeFunc(tfg, compose).Interface())
+}
+
+// Pipe has a parametric type:
+//
+//	func Pipe(f1 func(A) B, f2 func(B) C, ..., fn func(Y) Z) func(A) Z
+//
+// Pipe returns the composition of the functions in `fs` from left to right,
+// which is a function that applies the first function to its argument, then
+// applies the second function to the result and so on. Pipe is a variadic
+// Compose with its arguments in the opposite order, so that the functions
+// are listed in the order they are applied.
+//
+// Pipe panics if it is given no functions.
+func Pipe(fs ...interface{}) interface{} {
+	if len(fs) == 0 {
+		panic("Pipe needs at least one function")
+	}
+	piped := ty.Check(
+		new(func(func(ty.A) ty.B)),
+		fs[0]).Args[0].Interface()
+	for _, f := range fs[1:] {
+		piped = Compose(f, piped)
+	}
+	return piped
+}
+
+// Partial has a parametric type:
+//
+//	func Partial(f func(A1, ..., An, B1, ..., Bm) R, a1 A1, ..., an An)
+//		func(B1, ..., Bm) R
+//
+// Partial returns `f` with its first parameters bound to `args`. The result
+// is a function of the remaining parameters of `f` with the same results as
+// `f`. If `f` is variadic, only the parameters before the variadic parameter
+// may be bound. A nil argument is bound to the zero value of its parameter.
+//
+// Partial panics with a `TypeError` if `args` cannot be assigned to the
+// parameters of `f`.
+func Partial(f interface{}, args ...interface{}) interface{} {
+	vf := funcValue("Partial", f)
+	tf := vf.Type()
+
+	fixed := tf.NumIn()
+	if tf.IsVariadic() {
+		fixed--
+	}
+	if len(args) > fixed {
+		panic(ty.TypeError(fmt.Sprintf(
+			"Cannot bind %d arguments to '%s', which has only %d "+
+				"non-variadic parameters.", len(args), tf, fixed)))
+	}
+
+	bound := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		tparam := tf.In(i)
+		if arg == nil {
+			bound[i] = zeroValue(tparam)
+			continue
+		}
+		varg := reflect.ValueOf(arg)
+		if !varg.Type().AssignableTo(tparam) {
+			panic(ty.TypeError(fmt.Sprintf(
+				"Cannot bind argument %d of type '%s' to parameter of "+
+					"type '%s'.", i, varg.Type(), tparam)))
+		}
+		bound[i] = varg
+	}
+
+	ins := make([]reflect.Type, 0, tf.NumIn()-len(args))
+	for i := len(args); i < tf.NumIn(); i++ {
+		ins = append(ins, tf.In(i))
+	}
+	outs := make([]reflect.Type, tf.NumOut())
+	for i := range outs {
+		outs[i] = tf.Out(i)
+	}
+
+	partial := func(in []reflect.Value) []reflect.Value {
+		all := make([]reflect.Value, 0, len(bound)+len(in))
+		all = append(append(all, bound...), in...)
+		return callIn(vf, all)
+	}
+	tpartial := reflect.FuncOf(ins, outs, tf.IsVariadic())
+	return reflect.MakeFunc(tpartial, partial).Interface()
+}
+
+// Curry2 has a parametric type:
+//
+//	func Curry2(f func(A, B) C) func(A) func(B) C
+//
+// Curry2 returns a function that takes the first argument of `f` and
+// returns a function that takes the second argument of `f` and returns the
+// result of `f` applied to both.
+func Curry2(f interface{}) interface{} {
+	chk := ty.Check(
+		new(func(func(ty.A, ty.B) ty.C) func(ty.A) func(ty.B) ty.C),
+		f)
+	vf, tcurried := chk.Args[0], chk.Returns[0]
+
+	curried := func(in []reflect.Value) []reflect.Value {
+		va := in[0]
+		partial := func(in []reflect.Value) []reflect.Value {
+			return []reflect.Value{call1(vf, va, in[0])}
+		}
+		return []reflect.Value{reflect.MakeFunc(tcurried.Out(0), partial)}
+	}
+	return verified(chk, reflect.MakeFunc(tcurried, curried).Interface())
+}
+
+// Uncurry2 has a parametric type:
+//
+//	func Uncurry2(f func(A) func(B) C) func(A, B) C
+//
+// Uncurry2 is the inverse of Curry2. It returns a function of two arguments
+// that applies `f` to the first and the resulting function to the second.
+func Uncurry2(f interface{}) interface{} {
+	chk := ty.Check(
+		new(func(func(ty.A) func(ty.B) ty.C) func(ty.A, ty.B) ty.C),
+		f)
+	vf, tuncurried := chk.Args[0], chk.Returns[0]
+
+	uncurried := func(in []reflect.Value) []reflect.Value {
+		return []reflect.Value{call1(call1(vf, in[0]), in[1])}
+	}
+	return verified(chk, reflect.MakeFunc(tuncurried, uncurried).Interface())
+}
+
+// Flip has a parametric type:
+//
+//	func Flip(f func(A, B) C) func(B, A) C
+//
+// Flip returns `f` with the order of its two parameters reversed.
+func Flip(f interface{}) interface{} {
+	chk := ty.Check(
+		new(func(func(ty.A, ty.B) ty.C) func(ty.B, ty.A) ty.C),
+		f)
+	vf, tflipped := chk.Args[0], chk.Returns[0]
+
+	flipped := func(in []reflect.Value) []reflect.Value {
+		return []reflect.Value{call1(vf, in[1], in[0])}
+	}
+	return verified(chk, reflect.MakeFunc(tflipped, flipped).Interface())
+}
+
+// Once has a parametric type:
+//
+//	func Once(f F) F
+//
+// where `F` is any function type. Once returns a function that calls `f`
+// the first time it is called and returns the same results on every call
+// after that, regardless of its arguments. The returned function is safe to
+// call concurrently; concurrent callers wait for the first call to finish.
+func Once(f interface{}) interface{} {
+	vf := funcValue("Once", f)
+
+	var once sync.Once
+	var results []reflect.Value
+	oncef := func(in []reflect.Value) []reflect.Value {
+		once.Do(func() { results = callIn(vf, in) })
+		return results
+	}
+	return reflect.MakeFunc(vf.Type(), oncef).Interface()
+}
+
+// Debounce has a parametric type:
+//
+//	func Debounce(f F, wait time.Duration) F
+//
+// where `F` is any function type without results. Debounce returns a
+// function that delays calling `f` until `wait` has passed since it was
+// last called. `f` is then called once, in its own goroutine, with the
+// arguments of the last call.
+func Debounce(f interface{}, wait time.Duration) interface{} {
+	vf := funcValue("Debounce", f)
+	assertNoResults("Debounce", vf.Type())
+
+	// `calls` counts the calls of the debounced function, so that a timer
+	// that fired just before it was stopped does not call `f` again.
+	var mu sync.Mutex
+	var timer *time.Timer
+	calls := 0
+	debounced := func(in []reflect.Value) []reflect.Value {
+		mu.Lock()
+		defer mu.Unlock()
+
+		calls++
+		call := calls
+		if timer != nil {
+			timer.Stop()
+		}
+		timer = time.AfterFunc(wait, func() {
+			mu.Lock()
+			latest := call == calls
+			mu.Unlock()
+			if latest {
+				callIn(vf, in)
+			}
+		})
+		return nil
+	}
+	return reflect.MakeFunc(vf.Type(), debounced).Interface()
+}
+
+// Throttle has a parametric type:
+//
+//	func Throttle(f F, interval time.Duration) F
+//
+// where `F` is any function type without results. Throttle returns a
+// function that calls `f` at most once per `interval`. The first call calls
+// `f` immediately, and any call made less than `interval` after the last
+// call of `f` is dropped.
+func Throttle(f interface{}, interval time.Duration) interface{} {
+	vf := funcValue("Throttle", f)
+	assertNoResults("Throttle", vf.Type())
+
+	var mu sync.Mutex
+	var last time.Time
+	throttled := func(in []reflect.Value) []reflect.Value {
+		mu.Lock()
+		now := time.Now()
+		if !last.IsZero() && now.Sub(last) < interval {
+			mu.Unlock()
+			return nil
+		}
+		last = now
+		mu.Unlock()
+
+		callIn(vf, in)
+		return nil
+	}
+	return reflect.MakeFunc(vf.Type(), throttled).Interface()
+}
+
+// RetryPolicy determines how often and how quickly a function wrapped by
+// Retry is called again after it fails.
+type RetryPolicy struct {
+	// Attempts is the maximum number of times the function is called.
+	// Values less than 1 are treated as 1.
+	Attempts int
+
+	// Delay is how long to wait after the first failed attempt.
+	Delay time.Duration
+
+	// Backoff is the factor the delay is multiplied by after each failed
+	// attempt. Values less than 1 are treated as 1, i.e., a constant delay.
+	Backoff float64
+
+	// MaxDelay is the longest delay between attempts. It is ignored if it
+	// is not positive.
+	MaxDelay time.Duration
+
+	// Retryable reports whether an attempt that failed with the given error
+	// should be retried. If it is nil, every error is retried.
+	Retryable func(error) bool
+}
+
+// Retry has a parametric type:
+//
+//	func Retry(f F, policy RetryPolicy) F
+//
+// where `F` is any function type whose last result is an `error`. Retry
+// returns a function that calls `f` with its arguments until `f` returns a
+// nil error or `policy` says to stop, and returns the results of the last
+// call.
+func Retry(f interface{}, policy RetryPolicy) interface{} {
+	vf := funcValue("Retry", f)
+	tf := vf.Type()
+	terr := reflect.TypeOf((*error)(nil)).Elem()
+	if tf.NumOut() == 0 || tf.Out(tf.NumOut()-1) != terr {
+		panic(ty.TypeError(fmt.Sprintf(
+			"Retry needs a function whose last result is an error, "+
+				"but got '%s'.", tf)))
+	}
+
+	retry := func(in []reflect.Value) []reflect.Value {
+		delay := policy.Delay
+		for attempt := 1; ; attempt++ {
+			results := callIn(vf, in)
+			verr := results[len(results)-1]
+			if verr.IsNil() || attempt >= policy.Attempts {
+				return results
+			}
+			err := verr.Interface().(error)
+			if policy.Retryable != nil && !policy.Retryable(err) {
+				return results
+			}
+
+			time.Sleep(delay)
+			if policy.Backoff > 1 {
+				delay = time.Duration(float64(delay) * policy.Backoff)
+			}
+			if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+				delay = policy.MaxDelay
+			}
+		}
+	}
+	return reflect.MakeFunc(tf, retry).Interface()
+}
+
+// funcValue returns the value of `f`, or panics with a `TypeError` if `f`
+// is not a non-nil function.
+func funcValue(fname string, f interface{}) reflect.Value {
+	vf := reflect.ValueOf(f)
+	if vf.Kind() != reflect.Func || vf.IsNil() {
+		panic(ty.TypeError(fmt.Sprintf(
+			"%s needs a function, but got '%T'.", fname, f)))
+	}
+	return vf
+}
+
+// assertNoResults panics with a `TypeError` if the function type `tf` has
+// results.
+func assertNoResults(fname string, tf reflect.Type) {
+	if tf.NumOut() > 0 {
+		panic(ty.TypeError(fmt.Sprintf(
+			"%s needs a function without results, but got '%s'.",
+			fname, tf)))
+	}
+}
+
+// callIn calls `vf` with `in`, which holds the arguments of a function made
+// with `reflect.MakeFunc`. If `vf` is variadic, its variadic arguments are
+// already collected into a slice at the end of `in`.
+func callIn(vf reflect.Value, in []reflect.Value) []reflect.Value {
+	if vf.Type().IsVariadic() {
+		return vf.CallSlice(in)
+	}
+	return vf.Call(in)
+}
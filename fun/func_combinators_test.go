@@ -0,0 +1,149 @@
+package fun
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/ty"
+)
+
+func TestCompose(t *testing.T) {
+	length := func(s string) int { return len(s) }
+	itoa := Compose(length, strconv.Itoa).(func(int) int)
+	assertDeep(t, itoa(12345), 5)
+}
+
+func TestPipe(t *testing.T) {
+	double := func(n int) int { return n * 2 }
+	shout := Pipe(double, strconv.Itoa, strings.NewReader).(func(
+		int) *strings.Reader)
+	assertDeep(t, shout(21).Len(), 2)
+
+	id := Pipe(double).(func(int) int)
+	assertDeep(t, id(3), 6)
+}
+
+func TestPartial(t *testing.T) {
+	join := func(sep string, a, b string) string { return a + sep + b }
+	dash := Partial(join, "-").(func(string, string) string)
+	assertDeep(t, dash("a", "b"), "a-b")
+
+	dashA := Partial(join, "-", "a").(func(string) string)
+	assertDeep(t, dashA("z"), "a-z")
+
+	sprintf := Partial(fmt.Sprintf, "%d-%d").(func(...interface{}) string)
+	assertDeep(t, sprintf(1, 2), "1-2")
+
+	defer func() {
+		if _, ok := recover().(ty.TypeError); !ok {
+			t.Fatal("Partial with a bad argument did not panic")
+		}
+	}()
+	Partial(join, 5)
+}
+
+func TestCurry(t *testing.T) {
+	sub := func(a, b int) int { return a - b }
+	curried := Curry2(sub).(func(int) func(int) int)
+	assertDeep(t, curried(10)(3), 7)
+
+	uncurried := Uncurry2(curried).(func(int, int) int)
+	assertDeep(t, uncurried(10, 3), 7)
+}
+
+func TestFlip(t *testing.T) {
+	repeat := Flip(strings.Repeat).(func(int, string) string)
+	assertDeep(t, repeat(3, "ab"), "ababab")
+}
+
+func TestOnce(t *testing.T) {
+	calls := 0
+	inc := Once(func(n int) int {
+		calls++
+		return n + 1
+	}).(func(int) int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			inc(1)
+		}()
+	}
+	wg.Wait()
+	assertDeep(t, inc(100), 2)
+	assertDeep(t, calls, 1)
+}
+
+func TestDebounce(t *testing.T) {
+	got := make(chan int, 10)
+	send := Debounce(func(n int) { got <- n }, 20*time.Millisecond).(func(int))
+	for i := 1; i <= 5; i++ {
+		send(i)
+	}
+
+	select {
+	case n := <-got:
+		assertDeep(t, n, 5)
+	case <-time.After(time.Second):
+		t.Fatal("debounced function was never called")
+	}
+	select {
+	case n := <-got:
+		t.Fatalf("debounced function was called again with %d", n)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	var got []int
+	record := Throttle(func(n int) {
+		got = append(got, n)
+	}, time.Hour).(func(int))
+	for i := 1; i <= 5; i++ {
+		record(i)
+	}
+	assertDeep(t, got, []int{1})
+}
+
+func TestRetry(t *testing.T) {
+	errTemp, errFatal := errors.New("temporary"), errors.New("fatal")
+	attempts := 0
+	flaky := func(n int) (int, error) {
+		attempts++
+		if attempts < 3 {
+			return 0, errTemp
+		}
+		return n * 2, nil
+	}
+
+	f := Retry(flaky, RetryPolicy{Attempts: 5}).(func(int) (int, error))
+	n, err := f(21)
+	assertDeep(t, n, 42)
+	assertDeep(t, err, nil)
+	assertDeep(t, attempts, 3)
+
+	attempts = 0
+	f = Retry(flaky, RetryPolicy{Attempts: 2}).(func(int) (int, error))
+	_, err = f(21)
+	assertDeep(t, err, errTemp)
+	assertDeep(t, attempts, 2)
+
+	attempts = 0
+	fatal := func() error {
+		attempts++
+		return errFatal
+	}
+	g := Retry(fatal, RetryPolicy{
+		Attempts:  5,
+		Retryable: func(err error) bool { return err != errFatal },
+	}).(func() error)
+	assertDeep(t, g(), errFatal)
+	assertDeep(t, attempts, 1)
+}
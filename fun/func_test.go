@@ -109,3 +109,21 @@ func ExampleMemo() {
 	// Output:
 	// 23416728348467685
 }
+
+func TestMemoBy(t *testing.T) {
+	calls := 0
+	sum := func(xs []int) int {
+		calls++
+		s := 0
+		for _, x := range xs {
+			s += x
+		}
+		return s
+	}
+	msum := MemoBy(sum).(func([]int) int)
+
+	assertDeep(t, msum([]int{1, 2, 3}), 6)
+	assertDeep(t, msum([]int{1, 2, 3}), 6)
+	assertDeep(t, msum([]int{4}), 4)
+	assertDeep(t, calls, 2)
+}
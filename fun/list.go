@@ -26,7 +26,74 @@ func Map(f, xs interface{}) interface{} {
 		vy := call1(vf, vxs.Index(i))
 		vys.Index(i).Set(vy)
 	}
-	return vys.Interface()
+	return verified(chk, vys.Interface())
+}
+
+// MapIndexed has a parametric type:
+//
+//	func MapIndexed(f func(int, A) B, xs []A) []B
+//
+// MapIndexed is just like Map, except `f` is also given the index of each
+// element in `xs`.
+func MapIndexed(f, xs interface{}) interface{} {
+	chk := ty.Check(
+		new(func(func(int, ty.A) ty.B, []ty.A) []ty.B),
+		f, xs)
+	vf, vxs, tys := chk.Args[0], chk.Args[1], chk.Returns[0]
+
+	xsLen := vxs.Len()
+	vys := reflect.MakeSlice(tys, xsLen, xsLen)
+	for i := 0; i < xsLen; i++ {
+		vy := call1(vf, reflect.ValueOf(i), vxs.Index(i))
+		vys.Index(i).Set(vy)
+	}
+	return verified(chk, vys.Interface())
+}
+
+// FlatMap has a parametric type:
+//
+//	func FlatMap(f func(A) []B, xs []A) []B
+//
+// FlatMap applies `f` to each element in `xs` and returns all of the
+// resulting lists appended together.
+func FlatMap(f, xs interface{}) interface{} {
+	chk := ty.Check(
+		new(func(func(ty.A) []ty.B, []ty.A) []ty.B),
+		f, xs)
+	vf, vxs, tys := chk.Args[0], chk.Args[1], chk.Returns[0]
+
+	xsLen := vxs.Len()
+	vys := reflect.MakeSlice(tys, 0, xsLen)
+	for i := 0; i < xsLen; i++ {
+		vys = reflect.AppendSlice(vys, call1(vf, vxs.Index(i)))
+	}
+	return verified(chk, vys.Interface())
+}
+
+// Unfold has a parametric type:
+//
+//	func Unfold(f func(B) (A, B, bool), seed B) []A
+//
+// Unfold builds a list from a seed value, which is the opposite of a fold.
+// `f` is called with `seed` and returns the first element of the list, the
+// next seed and whether to continue. `f` is called with each new seed until
+// it returns false, and the element it returns with false is not included.
+func Unfold(f, seed interface{}) interface{} {
+	chk := ty.Check(
+		new(func(func(ty.B) (ty.A, ty.B, bool), ty.B) []ty.A),
+		f, seed)
+	vf, vseed, txs := chk.Args[0], chk.Args[1], chk.Returns[0]
+
+	assertCallable(vf)
+	vxs := reflect.MakeSlice(txs, 0, 10)
+	for {
+		ret := vf.Call([]reflect.Value{vseed})
+		if !ret[2].Bool() {
+			return verified(chk, vxs.Interface())
+		}
+		vxs = reflect.Append(vxs, ret[0])
+		vseed = ret[1]
+	}
 }
 
 // Filter has a parametric type:
@@ -49,7 +116,30 @@ func Filter(p, xs interface{}) interface{} {
 			vys = reflect.Append(vys, vx)
 		}
 	}
-	return vys.Interface()
+	return verified(chk, vys.Interface())
+}
+
+// FilterIndexed has a parametric type:
+//
+//	func FilterIndexed(p func(int, A) bool, xs []A) []A
+//
+// FilterIndexed is just like Filter, except `p` is also given the index of
+// each element in `xs`.
+func FilterIndexed(p, xs interface{}) interface{} {
+	chk := ty.Check(
+		new(func(func(int, ty.A) bool, []ty.A) []ty.A),
+		p, xs)
+	vp, vxs, tys := chk.Args[0], chk.Args[1], chk.Returns[0]
+
+	xsLen := vxs.Len()
+	vys := reflect.MakeSlice(tys, 0, xsLen)
+	for i := 0; i < xsLen; i++ {
+		vx := vxs.Index(i)
+		if call1(vp, reflect.ValueOf(i), vx).Bool() {
+			vys = reflect.Append(vys, vx)
+		}
+	}
+	return verified(chk, vys.Interface())
 }
 
 // Foldl has a parametric type:
@@ -68,14 +158,14 @@ func Foldl(f, init, xs interface{}) interface{} {
 	vb := zeroValue(tb)
 	vb.Set(vinit)
 	if xsLen == 0 {
-		return vb.Interface()
+		return verified(chk, vb.Interface())
 	}
 
 	vb.Set(call1(vf, vxs.Index(0), vb))
 	for i := 1; i < xsLen; i++ {
 		vb.Set(call1(vf, vxs.Index(i), vb))
 	}
-	return vb.Interface()
+	return verified(chk, vb.Interface())
 }
 
 // Foldr has a parametric type:
@@ -94,14 +184,86 @@ func Foldr(f, init, xs interface{}) interface{} {
 	vb := zeroValue(tb)
 	vb.Set(vinit)
 	if xsLen == 0 {
-		return vb.Interface()
+		return verified(chk, vb.Interface())
 	}
 
 	vb.Set(call1(vf, vxs.Index(xsLen-1), vb))
 	for i := xsLen - 2; i >= 0; i-- {
 		vb.Set(call1(vf, vxs.Index(i), vb))
 	}
-	return vb.Interface()
+	return verified(chk, vb.Interface())
+}
+
+// Scanl has a parametric type:
+//
+//	func Scanl(f func(A, B) B, init B, xs []A) []B
+//
+// Scanl is just like Foldl, except it returns every intermediate value of
+// the fold, starting with `init`. The last element is the result of Foldl,
+// and the result always has one more element than `xs`.
+func Scanl(f, init, xs interface{}) interface{} {
+	chk := ty.Check(
+		new(func(func(ty.A, ty.B) ty.B, ty.B, []ty.A) []ty.B),
+		f, init, xs)
+	vf, vinit, vxs, tbs := chk.Args[0], chk.Args[1], chk.Args[2], chk.Returns[0]
+
+	xsLen := vxs.Len()
+	vbs := reflect.MakeSlice(tbs, xsLen+1, xsLen+1)
+	vbs.Index(0).Set(vinit)
+	for i := 0; i < xsLen; i++ {
+		vbs.Index(i + 1).Set(call1(vf, vxs.Index(i), vbs.Index(i)))
+	}
+	return verified(chk, vbs.Interface())
+}
+
+// Scanr has a parametric type:
+//
+//	func Scanr(f func(A, B) B, init B, xs []A) []B
+//
+// Scanr is just like Foldr, except it returns every intermediate value of
+// the fold, ending with `init`. The first element is the result of Foldr,
+// and the result always has one more element than `xs`.
+func Scanr(f, init, xs interface{}) interface{} {
+	chk := ty.Check(
+		new(func(func(ty.A, ty.B) ty.B, ty.B, []ty.A) []ty.B),
+		f, init, xs)
+	vf, vinit, vxs, tbs := chk.Args[0], chk.Args[1], chk.Args[2], chk.Returns[0]
+
+	xsLen := vxs.Len()
+	vbs := reflect.MakeSlice(tbs, xsLen+1, xsLen+1)
+	vbs.Index(xsLen).Set(vinit)
+	for i := xsLen - 1; i >= 0; i-- {
+		vbs.Index(i).Set(call1(vf, vxs.Index(i), vbs.Index(i+1)))
+	}
+	return verified(chk, vbs.Interface())
+}
+
+// Reduce has a parametric type:
+//
+//	func Reduce(f func(A, A) A, xs []A) (A, bool)
+//
+// Reduce combines the elements of `xs` from left to right without an initial
+// value, i.e., `f(f(xs[0], xs[1]), xs[2])` and so on. If `xs` is empty,
+// Reduce returns the zero value of `A` and false.
+func Reduce(f, xs interface{}) (interface{}, bool) {
+	chk := ty.Check(
+		new(func(func(ty.A, ty.A) ty.A, []ty.A) (ty.A, bool)),
+		f, xs)
+	vf, vxs, ta := chk.Args[0], chk.Args[1], chk.Returns[0]
+
+	xsLen := vxs.Len()
+	if xsLen == 0 {
+		zero := zeroValue(ta).Interface()
+		chk.VerifyReturns(zero, false)
+		return zero, false
+	}
+	vacc := vxs.Index(0)
+	for i := 1; i < xsLen; i++ {
+		vacc = call1(vf, vacc, vxs.Index(i))
+	}
+	acc := vacc.Interface()
+	chk.VerifyReturns(acc, true)
+	return acc, true
 }
 
 // Concat has a parametric type:
@@ -120,7 +282,7 @@ func Concat(xs interface{}) interface{} {
 	for i := 0; i < xsLen; i++ {
 		vflat = reflect.AppendSlice(vflat, vxs.Index(i))
 	}
-	return vflat.Interface()
+	return verified(chk, vflat.Interface())
 }
 
 // Reverse has a parametric type:
@@ -139,7 +301,7 @@ func Reverse(xs interface{}) interface{} {
 	for i := 0; i < xsLen; i++ {
 		vys.Index(i).Set(vxs.Index(xsLen - 1 - i))
 	}
-	return vys.Interface()
+	return verified(chk, vys.Interface())
 }
 
 // Copy has a parametric type:
@@ -156,7 +318,7 @@ func Copy(xs interface{}) interface{} {
 	xsLen := vxs.Len()
 	vys := reflect.MakeSlice(tys, xsLen, xsLen)
 	reflect.Copy(vys, vxs)
-	return vys.Interface()
+	return verified(chk, vys.Interface())
 }
 
 // ParMap has a parametric type:
@@ -201,6 +363,10 @@ func ParMapN(f, xs interface{}, n int) interface{} {
 	if n < 1 {
 		n = 1
 	}
+	if xsLen > 0 {
+		// The workers cannot recover from a panic.
+		assertCallable(vf)
+	}
 	work := make(chan int, n)
 	wg := new(sync.WaitGroup)
 	for i := 0; i < n; i++ {
@@ -221,7 +387,7 @@ func ParMapN(f, xs interface{}, n int) interface{} {
 	}
 	close(work)
 	wg.Wait()
-	return ys.Interface()
+	return verified(chk, ys.Interface())
 }
 
 // Range generates a list of integers corresponding to every integer in
@@ -256,6 +422,23 @@ func Each(f, xs interface{}) {
 	}
 }
 
+// EachIndexed has a parametric type:
+//
+//  func EachIndexed(f func(int, A), xs []A)
+//
+// EachIndexed runs `f` across each element in `xs` along with its index.
+func EachIndexed(f, xs interface{}) {
+	chk := ty.Check(
+		new(func(func(int, ty.A), []ty.A)),
+		f, xs)
+	vf, vxs := chk.Args[0], chk.Args[1]
+
+	xsLen := vxs.Len()
+	for i := 0; i < xsLen; i++ {
+		call(vf, reflect.ValueOf(i), vxs.Index(i))
+	}
+}
+
 // GroupBy has a parametric type
 //
 //  func GroupBy(f func(A) B, xs []A) map[B][]A
@@ -280,7 +463,46 @@ func GroupBy(f, xs interface{}) interface{} {
 		vym.SetMapIndex(vz, reflect.Append(mi, vxs.Index(i)))
 	}
 
-	return vym.Interface()
+	return verified(chk, vym.Interface())
+}
+
+// GroupByKey has a parametric type
+//
+//  func GroupByKey(f func(A) B, xs []A) ([]B, [][]A)
+//
+// GroupByKey is just like GroupBy, except `B` may be any type, including
+// types that cannot be map keys such as slices, maps and functions. Keys are
+// compared with Equal. The distinct keys are returned in the order they were
+// first returned by f, along with the group of elements of xs for each key.
+func GroupByKey(f, xs interface{}) (interface{}, interface{}) {
+	chk := ty.Check(
+		new(func(func(ty.A) ty.B, []ty.A) ([]ty.B, [][]ty.A)),
+		f, xs)
+	vf, vxs := chk.Args[0], chk.Args[1]
+	tkeys, tgroups := chk.Returns[0], chk.Returns[1]
+
+	xsLen := vxs.Len()
+	groups := newDeepMap()
+	vkeys := reflect.MakeSlice(tkeys, 0, 0)
+	vgroups := reflect.MakeSlice(tgroups, 0, 0)
+	for i := 0; i < xsLen; i++ {
+		vz := call1(vf, vxs.Index(i))
+		gi, ok := groups.get(vz)
+		if !ok {
+			gi = reflect.ValueOf(vkeys.Len())
+			groups.put(vz, gi)
+			vkeys = reflect.Append(vkeys, vz)
+			vgroups = reflect.Append(vgroups,
+				reflect.MakeSlice(vxs.Type(), 0, 1))
+		}
+
+		vgroup := vgroups.Index(int(gi.Int()))
+		vgroup.Set(reflect.Append(vgroup, vxs.Index(i)))
+	}
+
+	keys, grouped := vkeys.Interface(), vgroups.Interface()
+	chk.VerifyReturns(keys, grouped)
+	return keys, grouped
 }
 
 // Zip has a parametric type
@@ -288,6 +510,8 @@ func GroupBy(f, xs interface{}) interface{} {
 //  func Zip(xs , ys []A) []A
 //
 // Zip puts the arrays xs and ys together interleaved until the shorter one runs out
+//
+// To combine lists of different types, see ZipWith and ZipPairs.
 func Zip(xs, ys interface{}) interface{} {
 	chk := ty.Check(
 		new(func([]ty.A, []ty.A) []ty.A),
@@ -307,7 +531,7 @@ func Zip(xs, ys interface{}) interface{} {
 		zs.Index(i*2 + 1).Set(vys.Index(i))
 	}
 
-	return zs.Interface()
+	return verified(chk, zs.Interface())
 }
 
 // Partition has a parametric type
@@ -336,7 +560,9 @@ func Partition(f, xs interface{}) (interface{}, interface{}) {
 		}
 	}
 
-	return rxs.Interface(), rys.Interface()
+	trues, falses := rxs.Interface(), rys.Interface()
+	chk.VerifyReturns(trues, falses)
+	return trues, falses
 }
 
 // Drop has a parametric type:
@@ -363,7 +589,7 @@ func Drop(f, xs interface{}) interface{} {
 		}
 	}
 
-	return vys.Interface()
+	return verified(chk, vys.Interface())
 }
 
 // Take has a parametric type:
@@ -384,10 +610,10 @@ func Take(f, xs interface{}) interface{} {
 	for i := 0; i < xsLen; i++ {
 		vx := vxs.Index(i)
 		if call1(vp, vx).Bool() {
-			return vys.Interface()
+			return verified(chk, vys.Interface())
 		}
 		vys = reflect.Append(vys, vx)
 	}
 
-	return vys.Interface()
+	return verified(chk, vys.Interface())
 }
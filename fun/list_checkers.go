@@ -10,7 +10,7 @@ import "github.com/BurntSushi/ty"
 // return true
 func All(f, xs interface{}) bool {
 	chk := ty.Check(
-		new(func(func(ty.A) bool, []ty.A)),
+		new(func(func(ty.A) bool, []ty.A) bool),
 		f, xs)
 	vp, vxs := chk.Args[0], chk.Args[1]
 
@@ -31,7 +31,7 @@ func All(f, xs interface{}) bool {
 // return true
 func Any(f, xs interface{}) bool {
 	chk := ty.Check(
-		new(func(func(ty.A) bool, []ty.A)),
+		new(func(func(ty.A) bool, []ty.A) bool),
 		f, xs)
 	vp, vxs := chk.Args[0], chk.Args[1]
 
@@ -52,7 +52,7 @@ func Any(f, xs interface{}) bool {
 // returns true
 func Count(f, xs interface{}) (matches int) {
 	chk := ty.Check(
-		new(func(func(ty.A) bool, []ty.A)),
+		new(func(func(ty.A) bool, []ty.A) int),
 		f, xs)
 	vp, vxs := chk.Args[0], chk.Args[1]
 
@@ -73,7 +73,7 @@ func Count(f, xs interface{}) (matches int) {
 // true, if none are returned it returns nil
 func Detect(f, xs interface{}) interface{} {
 	chk := ty.Check(
-		new(func(func(ty.A) bool, []ty.A)),
+		new(func(func(ty.A) bool, []ty.A) ty.A),
 		f, xs)
 	vp, vxs := chk.Args[0], chk.Args[1]
 
@@ -94,7 +94,7 @@ func Detect(f, xs interface{}) interface{} {
 // true, false otherwise
 func None(f, xs interface{}) bool {
 	chk := ty.Check(
-		new(func(func(ty.A) bool, []ty.A)),
+		new(func(func(ty.A) bool, []ty.A) bool),
 		f, xs)
 	vp, vxs := chk.Args[0], chk.Args[1]
 
@@ -115,7 +115,7 @@ func None(f, xs interface{}) bool {
 // caused f to return true
 func One(f, xs interface{}) bool {
 	chk := ty.Check(
-		new(func(func(ty.A) bool, []ty.A)),
+		new(func(func(ty.A) bool, []ty.A) bool),
 		f, xs)
 	vp, vxs := chk.Args[0], chk.Args[1]
 
@@ -26,5 +26,5 @@ func Replace(xs, ys interface{}) interface{} {
 		}
 	}
 
-	return vzs.Interface()
+	return verified(chk, vzs.Interface())
 }
@@ -1,7 +1,11 @@
 package fun
 
 import (
+	"strconv"
+	"strings"
 	"testing"
+
+	"github.com/BurntSushi/ty"
 )
 
 func TestMap(t *testing.T) {
@@ -56,6 +60,66 @@ func TestFoldr(t *testing.T) {
 	assertDeep(t, 0, Foldr(reducer, 0, []int{}).(int))
 }
 
+func TestScanl(t *testing.T) {
+	reducer := func(a, b int) int { return b % a }
+	assertDeep(t, Scanl(reducer, 7, []int{4, 5, 6}), []int{7, 3, 3, 3})
+	assertDeep(t, Scanl(reducer, 7, []int{}), []int{7})
+
+	count := func(s string, n int) int { return n + len(s) }
+	assertDeep(t, Scanl(count, 0, []string{"a", "bc", "def"}),
+		[]int{0, 1, 3, 6})
+}
+
+func TestScanr(t *testing.T) {
+	reducer := func(a, b int) int { return b % a }
+	assertDeep(t, Scanr(reducer, 7, []int{4, 5, 6}), []int{1, 1, 1, 7})
+	assertDeep(t, Scanr(reducer, 7, []int{}), []int{7})
+}
+
+func TestReduce(t *testing.T) {
+	sub := func(a, b int) int { return a - b }
+	v, ok := Reduce(sub, []int{10, 2, 3})
+	assertDeep(t, v, 5)
+	assertDeep(t, ok, true)
+
+	v, ok = Reduce(sub, []int{4})
+	assertDeep(t, v, 4)
+	assertDeep(t, ok, true)
+
+	v, ok = Reduce(sub, []int{})
+	assertDeep(t, v, 0)
+	assertDeep(t, ok, false)
+}
+
+func TestIndexed(t *testing.T) {
+	label := func(i int, s string) string { return s + strconv.Itoa(i) }
+	assertDeep(t, MapIndexed(label, []string{"a", "b"}), []string{"a0", "b1"})
+
+	evenIndex := func(i int, s string) bool { return i%2 == 0 }
+	assertDeep(t, FilterIndexed(evenIndex, []string{"a", "b", "c"}),
+		[]string{"a", "c"})
+
+	results := make([]string, 0)
+	EachIndexed(func(i int, s string) {
+		results = append(results, label(i, s))
+	}, []string{"x", "y"})
+	assertDeep(t, results, []string{"x0", "y1"})
+}
+
+func TestFlatMap(t *testing.T) {
+	dup := func(n int) []string {
+		return []string{strconv.Itoa(n), strconv.Itoa(n)}
+	}
+	assertDeep(t, FlatMap(dup, []int{1, 2}), []string{"1", "1", "2", "2"})
+	assertDeep(t, FlatMap(dup, []int{}), []string{})
+}
+
+func TestUnfold(t *testing.T) {
+	digits := func(n int) (int, int, bool) { return n % 10, n / 10, n > 0 }
+	assertDeep(t, Unfold(digits, 1234), []int{4, 3, 2, 1})
+	assertDeep(t, Unfold(digits, 0), []int{})
+}
+
 func TestConcat(t *testing.T) {
 	toflat := [][]int{
 		{1, 2, 3},
@@ -82,6 +146,20 @@ func TestGroupBy(t *testing.T) {
 	m = GroupBy(square, []int{})
 	assertDeep(t, m, map[int][]int{})
 }
+
+func TestGroupByKey(t *testing.T) {
+	digits := func(n int) []int {
+		if n < 10 {
+			return []int{n}
+		}
+		return []int{n / 10, n % 10}
+	}
+	keys, groups := GroupByKey(digits, []int{12, 3, 12, 45, 3})
+
+	assertDeep(t, keys, [][]int{{1, 2}, {3}, {4, 5}})
+	assertDeep(t, groups, [][]int{{12, 12}, {3, 3}, {45}})
+}
+
 func TestZip(t *testing.T) {
 	a := []int{1, 3, 5}
 	b := []int{2, 4, 6}
@@ -217,3 +295,41 @@ func benchmarkMapPrimeBuiltin(b *testing.B) {
 		}
 	}
 }
+
+func TestUntypedNil(t *testing.T) {
+	assertDeep(t, Map(strconv.Itoa, nil), []string{})
+	assertDeep(t, Filter(func(n int) bool { return true }, nil), []int{})
+	assertDeep(t, Foldl(func(n, acc int) int { return n + acc }, 5, nil), 5)
+	assertDeep(t, Concat([][]int(nil)), []int{})
+	assertDeep(t, Reverse([]int(nil)), []int{})
+
+	defer func() {
+		if _, ok := recover().(ty.TypeError); !ok {
+			t.Fatal("Reverse(nil) did not panic with a TypeError.")
+		}
+	}()
+	Reverse(nil)
+}
+
+func TestNilFunction(t *testing.T) {
+	assertDeep(t, Filter(nil, []int{}), []int{})
+
+	tests := []func(){
+		func() { Filter(nil, []int{1, 2}) },
+		func() { Map((func(int) int)(nil), []int{1}) },
+		func() { ParMap((func(int) int)(nil), []int{1}) },
+		func() { Unfold((func(int) (int, int, bool))(nil), 1) },
+	}
+	for i, test := range tests {
+		func() {
+			defer func() {
+				err, ok := recover().(ty.TypeError)
+				if !ok || !strings.Contains(err.Error(), "nil function") {
+					t.Errorf("Test %d did not panic with a TypeError about "+
+						"a nil function: %v", i, err)
+				}
+			}()
+			test()
+		}()
+	}
+}
@@ -0,0 +1,255 @@
+package fun
+
+import (
+	"reflect"
+
+	"github.com/BurntSushi/ty"
+)
+
+// Chunk has a parametric type:
+//
+//	func Chunk(xs []A, n int) [][]A
+//
+// Chunk splits `xs` into consecutive lists of `n` elements. The last list
+// is shorter than `n` if the length of `xs` is not a multiple of `n`.
+//
+// Chunk will panic if `n` is less than 1.
+func Chunk(xs interface{}, n int) interface{} {
+	chk := ty.Check(
+		new(func([]ty.A, int) [][]ty.A),
+		xs, n)
+	vxs, tchunks := chk.Args[0], chk.Returns[0]
+
+	if n < 1 {
+		panic("chunk size must be at least 1")
+	}
+	xsLen := vxs.Len()
+	vchunks := reflect.MakeSlice(tchunks, 0, (xsLen+n-1)/n)
+	for i := 0; i < xsLen; i += n {
+		end := i + n
+		if end > xsLen {
+			end = xsLen
+		}
+		vchunk := copySlice(tchunks.Elem(), vxs.Slice(i, end))
+		vchunks = reflect.Append(vchunks, vchunk)
+	}
+	return verified(chk, vchunks.Interface())
+}
+
+// Window has a parametric type:
+//
+//	func Window(xs []A, size, step int) [][]A
+//
+// Window returns every list of `size` consecutive elements of `xs`, where
+// each list starts `step` elements after the previous one. Windows overlap
+// if `step` is less than `size` and skip elements if `step` is greater than
+// `size`. Only complete windows are returned, so there are none if `xs` has
+// fewer than `size` elements.
+//
+// Window will panic if `size` or `step` is less than 1.
+func Window(xs interface{}, size, step int) interface{} {
+	chk := ty.Check(
+		new(func([]ty.A, int, int) [][]ty.A),
+		xs, size, step)
+	vxs, twins := chk.Args[0], chk.Returns[0]
+
+	if size < 1 || step < 1 {
+		panic("window size and step must be at least 1")
+	}
+	xsLen := vxs.Len()
+	vwins := reflect.MakeSlice(twins, 0, 0)
+	for i := 0; i+size <= xsLen; i += step {
+		vwin := copySlice(twins.Elem(), vxs.Slice(i, i+size))
+		vwins = reflect.Append(vwins, vwin)
+	}
+	return verified(chk, vwins.Interface())
+}
+
+// SplitAt has a parametric type:
+//
+//	func SplitAt(xs []A, i int) ([]A, []A)
+//
+// SplitAt returns new lists of the first `i` elements of `xs` and the
+// remaining elements of `xs`. `i` is clamped to the bounds of `xs`.
+func SplitAt(xs interface{}, i int) (interface{}, interface{}) {
+	chk := ty.Check(
+		new(func([]ty.A, int) ([]ty.A, []ty.A)),
+		xs, i)
+	vxs, tys := chk.Args[0], chk.Returns[0]
+
+	i = clamp(i, vxs.Len())
+	ys := copySlice(tys, vxs.Slice(0, i)).Interface()
+	zs := copySlice(tys, vxs.Slice(i, vxs.Len())).Interface()
+	chk.VerifyReturns(ys, zs)
+	return ys, zs
+}
+
+// SplitWhen has a parametric type:
+//
+//	func SplitWhen(p func(A) bool, xs []A) [][]A
+//
+// SplitWhen splits `xs` into the lists of elements between the elements
+// that satisfy `p`, which are dropped. For example, splitting
+// [1 2 0 3 0 0 4] when an element is 0 returns [[1 2] [3] [] [4]].
+func SplitWhen(p, xs interface{}) interface{} {
+	chk := ty.Check(
+		new(func(func(ty.A) bool, []ty.A) [][]ty.A),
+		p, xs)
+	vp, vxs, tparts := chk.Args[0], chk.Args[1], chk.Returns[0]
+
+	xsLen := vxs.Len()
+	vparts := reflect.MakeSlice(tparts, 0, 1)
+	start := 0
+	for i := 0; i < xsLen; i++ {
+		if call1(vp, vxs.Index(i)).Bool() {
+			vpart := copySlice(tparts.Elem(), vxs.Slice(start, i))
+			vparts = reflect.Append(vparts, vpart)
+			start = i + 1
+		}
+	}
+	vpart := copySlice(tparts.Elem(), vxs.Slice(start, xsLen))
+	vparts = reflect.Append(vparts, vpart)
+	return verified(chk, vparts.Interface())
+}
+
+// Interleave has a parametric type:
+//
+//	func Interleave(xss [][]A) []A
+//
+// Interleave takes the first element of each list in `xss`, then the second
+// element of each list and so on. Unlike Zip, lists that run out are
+// skipped, so every element of every list is in the result.
+func Interleave(xss interface{}) interface{} {
+	chk := ty.Check(
+		new(func([][]ty.A) []ty.A),
+		xss)
+	vxss, tys := chk.Args[0], chk.Returns[0]
+
+	xssLen, total, longest := vxss.Len(), 0, 0
+	for i := 0; i < xssLen; i++ {
+		total += vxss.Index(i).Len()
+		if l := vxss.Index(i).Len(); l > longest {
+			longest = l
+		}
+	}
+
+	vys := reflect.MakeSlice(tys, 0, total)
+	for j := 0; j < longest; j++ {
+		for i := 0; i < xssLen; i++ {
+			if vxs := vxss.Index(i); j < vxs.Len() {
+				vys = reflect.Append(vys, vxs.Index(j))
+			}
+		}
+	}
+	return verified(chk, vys.Interface())
+}
+
+// Transpose has a parametric type:
+//
+//	func Transpose(xss [][]A) [][]A
+//
+// Transpose returns a list whose ith list is made of the ith element of
+// each list in `xss`. If the lists in `xss` have different lengths, missing
+// elements are skipped, e.g., transposing [[1 2 3] [4] [5 6]] returns
+// [[1 4 5] [2 6] [3]].
+func Transpose(xss interface{}) interface{} {
+	chk := ty.Check(
+		new(func([][]ty.A) [][]ty.A),
+		xss)
+	vxss, tyss := chk.Args[0], chk.Returns[0]
+
+	xssLen, longest := vxss.Len(), 0
+	for i := 0; i < xssLen; i++ {
+		if l := vxss.Index(i).Len(); l > longest {
+			longest = l
+		}
+	}
+
+	vyss := reflect.MakeSlice(tyss, longest, longest)
+	for j := 0; j < longest; j++ {
+		vys := reflect.MakeSlice(tyss.Elem(), 0, xssLen)
+		for i := 0; i < xssLen; i++ {
+			if vxs := vxss.Index(i); j < vxs.Len() {
+				vys = reflect.Append(vys, vxs.Index(j))
+			}
+		}
+		vyss.Index(j).Set(vys)
+	}
+	return verified(chk, vyss.Interface())
+}
+
+// Rotate has a parametric type:
+//
+//	func Rotate(xs []A, k int) []A
+//
+// Rotate returns a new list of the elements of `xs` rotated `k` places to
+// the left, so that the element at index `k` becomes the first element. A
+// negative `k` rotates to the right.
+func Rotate(xs interface{}, k int) interface{} {
+	chk := ty.Check(
+		new(func([]ty.A, int) []ty.A),
+		xs, k)
+	vxs, tys := chk.Args[0], chk.Returns[0]
+
+	xsLen := vxs.Len()
+	vys := reflect.MakeSlice(tys, xsLen, xsLen)
+	if xsLen == 0 {
+		return verified(chk, vys.Interface())
+	}
+	k = ((k % xsLen) + xsLen) % xsLen
+	reflect.Copy(vys, vxs.Slice(k, xsLen))
+	reflect.Copy(vys.Slice(xsLen-k, xsLen), vxs.Slice(0, k))
+	return verified(chk, vys.Interface())
+}
+
+// TakeN has a parametric type:
+//
+//	func TakeN(xs []A, n int) []A
+//
+// TakeN returns a new list of the first `n` elements of `xs`, or all of
+// `xs` if it has fewer than `n` elements.
+func TakeN(xs interface{}, n int) interface{} {
+	chk := ty.Check(
+		new(func([]ty.A, int) []ty.A),
+		xs, n)
+	vxs, tys := chk.Args[0], chk.Returns[0]
+
+	ys := copySlice(tys, vxs.Slice(0, clamp(n, vxs.Len())))
+	return verified(chk, ys.Interface())
+}
+
+// DropN has a parametric type:
+//
+//	func DropN(xs []A, n int) []A
+//
+// DropN returns a new list of the elements of `xs` after the first `n`, or
+// an empty list if `xs` has fewer than `n` elements.
+func DropN(xs interface{}, n int) interface{} {
+	chk := ty.Check(
+		new(func([]ty.A, int) []ty.A),
+		xs, n)
+	vxs, tys := chk.Args[0], chk.Returns[0]
+
+	xsLen := vxs.Len()
+	ys := copySlice(tys, vxs.Slice(clamp(n, xsLen), xsLen))
+	return verified(chk, ys.Interface())
+}
+
+// copySlice returns a copy of the slice `vxs` with type `tys` that shares no
+// memory with it.
+func copySlice(tys reflect.Type, vxs reflect.Value) reflect.Value {
+	vys := reflect.MakeSlice(tys, vxs.Len(), vxs.Len())
+	reflect.Copy(vys, vxs)
+	return vys
+}
+
+// clamp returns `i` clamped to the interval [0, n].
+func clamp(i, n int) int {
+	switch {
+	case i < 0:
+		return 0
+	case i > n:
+		return n
+	}
+	return i
+}
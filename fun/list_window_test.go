@@ -0,0 +1,74 @@
+package fun
+
+import "testing"
+
+type ints []int
+
+func TestChunk(t *testing.T) {
+	assertDeep(t, Chunk(Range(0, 7), 3), [][]int{{0, 1, 2}, {3, 4, 5}, {6}})
+	assertDeep(t, Chunk(Range(0, 6), 3), [][]int{{0, 1, 2}, {3, 4, 5}})
+	assertDeep(t, Chunk([]string{}, 3), [][]string{})
+
+	xs := Range(0, 4)
+	chunks := Chunk(xs, 2).([][]int)
+	chunks[0][0] = 99
+	assertDeep(t, xs, []int{0, 1, 2, 3})
+}
+
+func TestWindow(t *testing.T) {
+	assertDeep(t, Window(Range(0, 5), 3, 1),
+		[][]int{{0, 1, 2}, {1, 2, 3}, {2, 3, 4}})
+	assertDeep(t, Window(Range(0, 7), 2, 3), [][]int{{0, 1}, {3, 4}})
+	assertDeep(t, Window(Range(0, 2), 3, 1), [][]int{})
+}
+
+func TestSplitAt(t *testing.T) {
+	xs, ys := SplitAt(Range(0, 5), 2)
+	assertDeep(t, xs, []int{0, 1})
+	assertDeep(t, ys, []int{2, 3, 4})
+
+	xs, ys = SplitAt(ints{1, 2}, 10)
+	assertDeep(t, xs, []int{1, 2})
+	assertDeep(t, ys, []int{})
+
+	xs, ys = SplitAt(Range(0, 2), -1)
+	assertDeep(t, xs, []int{})
+	assertDeep(t, ys, []int{0, 1})
+}
+
+func TestSplitWhen(t *testing.T) {
+	zero := func(n int) bool { return n == 0 }
+	assertDeep(t, SplitWhen(zero, []int{1, 2, 0, 3, 0, 0, 4}),
+		[][]int{{1, 2}, {3}, {}, {4}})
+	assertDeep(t, SplitWhen(zero, []int{0}), [][]int{{}, {}})
+	assertDeep(t, SplitWhen(zero, []int{}), [][]int{{}})
+}
+
+func TestInterleave(t *testing.T) {
+	xss := [][]string{{"a", "b", "c"}, {"1"}, {"x", "y"}}
+	assertDeep(t, Interleave(xss), []string{"a", "1", "x", "b", "y", "c"})
+	assertDeep(t, Interleave([][]string{}), []string{})
+}
+
+func TestTranspose(t *testing.T) {
+	assertDeep(t, Transpose([][]int{{1, 2, 3}, {4, 5, 6}}),
+		[][]int{{1, 4}, {2, 5}, {3, 6}})
+	assertDeep(t, Transpose([][]int{{1, 2, 3}, {4}, {5, 6}}),
+		[][]int{{1, 4, 5}, {2, 6}, {3}})
+	assertDeep(t, Transpose([][]int{}), [][]int{})
+}
+
+func TestRotate(t *testing.T) {
+	assertDeep(t, Rotate(Range(0, 5), 2), []int{2, 3, 4, 0, 1})
+	assertDeep(t, Rotate(Range(0, 5), -1), []int{4, 0, 1, 2, 3})
+	assertDeep(t, Rotate(Range(0, 5), 12), []int{2, 3, 4, 0, 1})
+	assertDeep(t, Rotate([]int{}, 3), []int{})
+}
+
+func TestTakeDropN(t *testing.T) {
+	assertDeep(t, TakeN(Range(0, 5), 2), []int{0, 1})
+	assertDeep(t, TakeN(Range(0, 5), 10), []int{0, 1, 2, 3, 4})
+	assertDeep(t, DropN(Range(0, 5), 2), []int{2, 3, 4})
+	assertDeep(t, DropN(Range(0, 5), 10), []int{})
+	assertDeep(t, TakeN(ints{1, 2}, 1), []int{1})
+}
@@ -0,0 +1,186 @@
+package fun
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/BurntSushi/ty"
+)
+
+// ZipWith has a parametric type:
+//
+//	func ZipWith(f func(A, B) C, xs []A, ys []B) []C
+//
+// ZipWith returns the list of results of applying `f` to the elements of
+// `xs` and `ys` at the same index, until the shorter list runs out.
+func ZipWith(f, xs, ys interface{}) interface{} {
+	chk := ty.Check(
+		new(func(func(ty.A, ty.B) ty.C, []ty.A, []ty.B) []ty.C),
+		f, xs, ys)
+	vf, vxs, vys, tzs := chk.Args[0], chk.Args[1], chk.Args[2], chk.Returns[0]
+
+	zsLen := vxs.Len()
+	if vys.Len() < zsLen {
+		zsLen = vys.Len()
+	}
+	vzs := reflect.MakeSlice(tzs, zsLen, zsLen)
+	for i := 0; i < zsLen; i++ {
+		vzs.Index(i).Set(call1(vf, vxs.Index(i), vys.Index(i)))
+	}
+	return verified(chk, vzs.Interface())
+}
+
+// ZipPairs has a parametric type:
+//
+//	func ZipPairs(xs []A, ys []B) []struct{ First A; Second B }
+//
+// ZipPairs returns the list of pairs of elements of `xs` and `ys` at the
+// same index, until the shorter list runs out. Each pair is a struct whose
+// type is built at run time, so the result can be type asserted like any
+// other:
+//
+//	pairs := ZipPairs([]string{"a", "b"}, []int{1, 2}).([]struct {
+//		First  string
+//		Second int
+//	})
+func ZipPairs(xs, ys interface{}) interface{} {
+	chk := ty.Check(
+		new(func([]ty.A, []ty.B) []struct {
+			First  ty.A
+			Second ty.B
+		}),
+		xs, ys)
+	vxs, vys := chk.Args[0], chk.Args[1]
+
+	tpair := pairOf(vxs.Type().Elem(), vys.Type().Elem())
+	pairsLen := vxs.Len()
+	if vys.Len() < pairsLen {
+		pairsLen = vys.Len()
+	}
+	vpairs := reflect.MakeSlice(reflect.SliceOf(tpair), pairsLen, pairsLen)
+	for i := 0; i < pairsLen; i++ {
+		vpair := vpairs.Index(i)
+		vpair.Field(0).Set(vxs.Index(i))
+		vpair.Field(1).Set(vys.Index(i))
+	}
+	return verified(chk, vpairs.Interface())
+}
+
+// Unzip has a parametric type:
+//
+//	func Unzip(pairs []struct{ First A; Second B }) ([]A, []B)
+//
+// Unzip is the inverse of ZipPairs. It returns the list of the first
+// elements of each pair and the list of the second elements of each pair.
+//
+// Unzip panics with a `TypeError` if `pairs` is not a slice of structs with
+// exactly two fields named `First` and `Second`.
+func Unzip(pairs interface{}) (interface{}, interface{}) {
+	chk := ty.Check(
+		new(func([]ty.A)),
+		pairs)
+	vpairs := chk.Args[0]
+
+	tpair := vpairs.Type().Elem()
+	if tpair.Kind() != reflect.Struct || tpair.NumField() != 2 ||
+		tpair.Field(0).Name != "First" || tpair.Field(1).Name != "Second" {
+		panic(ty.TypeError(fmt.Sprintf(
+			"Expected a slice of pairs with fields 'First' and 'Second', "+
+				"but got '%s'.", vpairs.Type())))
+	}
+
+	pairsLen := vpairs.Len()
+	vxs := reflect.MakeSlice(
+		reflect.SliceOf(tpair.Field(0).Type), pairsLen, pairsLen)
+	vys := reflect.MakeSlice(
+		reflect.SliceOf(tpair.Field(1).Type), pairsLen, pairsLen)
+	for i := 0; i < pairsLen; i++ {
+		vxs.Index(i).Set(vpairs.Index(i).Field(0))
+		vys.Index(i).Set(vpairs.Index(i).Field(1))
+	}
+	return vxs.Interface(), vys.Interface()
+}
+
+// ZipMode determines how ZipAll handles lists of unequal lengths.
+type ZipMode int
+
+const (
+	// ZipTruncate stops at the end of the shortest list.
+	ZipTruncate ZipMode = iota
+
+	// ZipPad continues until the end of the longest list, using the zero
+	// value in place of the elements of shorter lists.
+	ZipPad
+
+	// ZipStrict requires that all lists have the same length.
+	ZipStrict
+)
+
+// ZipAll has a parametric type:
+//
+//	func ZipAll(mode ZipMode, xs0 []A0, xs1 []A1, ...) (
+//		[]struct{ V0 A0; V1 A1; ... }, error)
+//
+// ZipAll is an N-ary ZipPairs. It returns a list of tuples, where the tuple
+// at index i is a struct whose field `Vj` is the element at index i of the
+// jth list. Every list may have a different element type. When the lists
+// have different lengths, `mode` determines whether the result is
+// truncated, padded with zero values or an error is returned.
+//
+// ZipAll panics if it is given no lists.
+func ZipAll(mode ZipMode, xss ...interface{}) (interface{}, error) {
+	if len(xss) == 0 {
+		panic("ZipAll needs at least one list")
+	}
+
+	vxss := make([]reflect.Value, len(xss))
+	fields := make([]reflect.StructField, len(xss))
+	shortest, longest := -1, 0
+	for j, xs := range xss {
+		vxss[j] = ty.Check(new(func([]ty.A)), xs).Args[0]
+		fields[j] = reflect.StructField{
+			Name: fmt.Sprintf("V%d", j),
+			Type: vxss[j].Type().Elem(),
+		}
+
+		xsLen := vxss[j].Len()
+		if shortest == -1 || xsLen < shortest {
+			shortest = xsLen
+		}
+		if xsLen > longest {
+			longest = xsLen
+		}
+	}
+
+	tupsLen := shortest
+	switch mode {
+	case ZipPad:
+		tupsLen = longest
+	case ZipStrict:
+		if shortest != longest {
+			return nil, fmt.Errorf(
+				"cannot zip lists with lengths between %d and %d",
+				shortest, longest)
+		}
+	}
+
+	ttup := reflect.StructOf(fields)
+	vtups := reflect.MakeSlice(reflect.SliceOf(ttup), tupsLen, tupsLen)
+	for i := 0; i < tupsLen; i++ {
+		vtup := vtups.Index(i)
+		for j, vxs := range vxss {
+			if i < vxs.Len() {
+				vtup.Field(j).Set(vxs.Index(i))
+			}
+		}
+	}
+	return vtups.Interface(), nil
+}
+
+// pairOf returns the type `struct { First A; Second B }`.
+func pairOf(ta, tb reflect.Type) reflect.Type {
+	return reflect.StructOf([]reflect.StructField{
+		{Name: "First", Type: ta},
+		{Name: "Second", Type: tb},
+	})
+}
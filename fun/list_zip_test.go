@@ -0,0 +1,73 @@
+package fun
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/ty"
+)
+
+func TestZipWith(t *testing.T) {
+	repeat := func(s string, n int) string {
+		r := ""
+		for i := 0; i < n; i++ {
+			r += s
+		}
+		return r
+	}
+	zs := ZipWith(repeat, []string{"a", "b", "c"}, []int{1, 2})
+	assertDeep(t, zs, []string{"a", "bb"})
+	assertDeep(t, ZipWith(repeat, []string{}, []int{1}), []string{})
+}
+
+func TestZipPairs(t *testing.T) {
+	pairs := ZipPairs([]string{"a", "b", "c"}, []int{1, 2}).([]struct {
+		First  string
+		Second int
+	})
+	assertDeep(t, len(pairs), 2)
+	assertDeep(t, pairs[1].First, "b")
+	assertDeep(t, pairs[1].Second, 2)
+
+	xs, ys := Unzip(pairs)
+	assertDeep(t, xs, []string{"a", "b"})
+	assertDeep(t, ys, []int{1, 2})
+}
+
+func TestUnzipNotPairs(t *testing.T) {
+	defer func() {
+		if _, ok := recover().(ty.TypeError); !ok {
+			t.Fatal("unzipping a list of ints should panic with a TypeError")
+		}
+	}()
+	Unzip([]int{1, 2})
+}
+
+func TestZipAll(t *testing.T) {
+	xs, ys, zs := []int{1, 2, 3}, []string{"a", "b"}, []bool{true, false}
+
+	tups, err := ZipAll(ZipTruncate, xs, ys, zs)
+	assertDeep(t, err, nil)
+	assertDeep(t, tups, []struct {
+		V0 int
+		V1 string
+		V2 bool
+	}{{1, "a", true}, {2, "b", false}})
+
+	tups, err = ZipAll(ZipPad, xs, ys)
+	assertDeep(t, err, nil)
+	assertDeep(t, tups, []struct {
+		V0 int
+		V1 string
+	}{{1, "a"}, {2, "b"}, {3, ""}})
+
+	_, err = ZipAll(ZipStrict, xs, ys)
+	if err == nil {
+		t.Fatal("ZipStrict should fail on lists of different lengths")
+	}
+	tups, err = ZipAll(ZipStrict, ys, zs)
+	assertDeep(t, err, nil)
+	assertDeep(t, tups, []struct {
+		V0 string
+		V1 bool
+	}{{"a", true}, {"b", false}})
+}
@@ -1,6 +1,7 @@
 package fun
 
 import (
+	"fmt"
 	"reflect"
 
 	"github.com/BurntSushi/ty"
@@ -21,7 +22,7 @@ func Keys(m interface{}) interface{} {
 	for i, vkey := range vm.MapKeys() {
 		vkeys.Index(i).Set(vkey)
 	}
-	return vkeys.Interface()
+	return verified(chk, vkeys.Interface())
 }
 
 // Values has a parametric type:
@@ -39,8 +40,221 @@ func Values(m interface{}) interface{} {
 	for i, vkey := range vm.MapKeys() {
 		vvals.Index(i).Set(vm.MapIndex(vkey))
 	}
-	return vvals.Interface()
+	return verified(chk, vvals.Interface())
 }
 
-// func MapMerge(m1, m2 interface{}) interface{} {
-// }
+// MapMerge has a parametric type:
+//
+//	func MapMerge(m1, m2 map[K]V, conflict func(K, V, V) V) map[K]V
+//
+// MapMerge returns a new map with every key and value in `m1` and `m2`. When
+// a key is in both maps, its value is the result of `conflict` applied to
+// the key, the value in `m1` and the value in `m2`. If `conflict` is nil, the
+// value in `m2` is used. The maps `m1` and `m2` are not modified.
+func MapMerge(m1, m2, conflict interface{}) interface{} {
+	var chk *ty.Typed
+	var vconflict reflect.Value
+	if conflict == nil {
+		chk = ty.Check(
+			new(func(map[ty.A]ty.B, map[ty.A]ty.B) map[ty.A]ty.B),
+			m1, m2)
+	} else {
+		chk = ty.Check(
+			new(func(map[ty.A]ty.B, map[ty.A]ty.B,
+				func(ty.A, ty.B, ty.B) ty.B) map[ty.A]ty.B),
+			m1, m2, conflict)
+		vconflict = chk.Args[2]
+	}
+	vm1, vm2, tm := chk.Args[0], chk.Args[1], chk.Returns[0]
+
+	vm := reflect.MakeMapWithSize(tm, vm1.Len())
+	for iter := vm1.MapRange(); iter.Next(); {
+		vm.SetMapIndex(iter.Key(), iter.Value())
+	}
+	for iter := vm2.MapRange(); iter.Next(); {
+		vkey, vval := iter.Key(), iter.Value()
+		if vold := vm.MapIndex(vkey); vold.IsValid() && vconflict.IsValid() {
+			vval = call1(vconflict, vkey, vold, vval)
+		}
+		vm.SetMapIndex(vkey, vval)
+	}
+	return verified(chk, vm.Interface())
+}
+
+// MapFilter has a parametric type:
+//
+//	func MapFilter(p func(K, V) bool, m map[K]V) map[K]V
+//
+// MapFilter returns a new map with only the keys and values in `m` that
+// satisfy the predicate `p`.
+func MapFilter(p, m interface{}) interface{} {
+	chk := ty.Check(
+		new(func(func(ty.A, ty.B) bool, map[ty.A]ty.B) map[ty.A]ty.B),
+		p, m)
+	vp, vm, tm := chk.Args[0], chk.Args[1], chk.Returns[0]
+
+	vfiltered := reflect.MakeMap(tm)
+	for iter := vm.MapRange(); iter.Next(); {
+		if call1(vp, iter.Key(), iter.Value()).Bool() {
+			vfiltered.SetMapIndex(iter.Key(), iter.Value())
+		}
+	}
+	return verified(chk, vfiltered.Interface())
+}
+
+// MapValues has a parametric type:
+//
+//	func MapValues(f func(V) W, m map[K]V) map[K]W
+//
+// MapValues returns a new map with the same keys as `m`, where each value
+// is the result of `f` applied to the value of the key in `m`.
+func MapValues(f, m interface{}) interface{} {
+	chk := ty.Check(
+		new(func(func(ty.B) ty.C, map[ty.A]ty.B) map[ty.A]ty.C),
+		f, m)
+	vf, vm, tm := chk.Args[0], chk.Args[1], chk.Returns[0]
+
+	vmapped := reflect.MakeMapWithSize(tm, vm.Len())
+	for iter := vm.MapRange(); iter.Next(); {
+		vmapped.SetMapIndex(iter.Key(), call1(vf, iter.Value()))
+	}
+	return verified(chk, vmapped.Interface())
+}
+
+// MapKeys has a parametric type:
+//
+//	func MapKeys(f func(K) J, m map[K]V) map[J]V
+//
+// MapKeys returns a new map with the same values as `m`, where each key is
+// the result of `f` applied to a key in `m`. If `f` returns the same key
+// for more than one key in `m`, which of their values is kept is
+// unspecified.
+func MapKeys(f, m interface{}) interface{} {
+	chk := ty.Check(
+		new(func(func(ty.A) ty.C, map[ty.A]ty.B)),
+		f, m)
+	vf, vm := chk.Args[0], chk.Args[1]
+	tkey := vf.Type().Out(0)
+	assertComparable("MapKeys", tkey)
+
+	tm := reflect.MapOf(tkey, vm.Type().Elem())
+	vmapped := reflect.MakeMapWithSize(tm, vm.Len())
+	for iter := vm.MapRange(); iter.Next(); {
+		vmapped.SetMapIndex(call1(vf, iter.Key()), iter.Value())
+	}
+	return vmapped.Interface()
+}
+
+// Invert has a parametric type:
+//
+//	func Invert(m map[K]V) map[V]K
+//
+// Invert returns a new map from each value in `m` to its key. If a value
+// occurs more than once in `m`, which of its keys is kept is unspecified.
+// `V` must be a valid map key type.
+func Invert(m interface{}) interface{} {
+	chk := ty.Check(
+		new(func(map[ty.A]ty.B)),
+		m)
+	vm := chk.Args[0]
+	assertComparable("Invert", vm.Type().Elem())
+
+	tm := reflect.MapOf(vm.Type().Elem(), vm.Type().Key())
+	vinv := reflect.MakeMapWithSize(tm, vm.Len())
+	for iter := vm.MapRange(); iter.Next(); {
+		vinv.SetMapIndex(iter.Value(), iter.Key())
+	}
+	return vinv.Interface()
+}
+
+// Entries has a parametric type:
+//
+//	func Entries(m map[K]V) []struct{ Key K; Value V }
+//
+// Entries returns a list of the keys and values of `m` in an unspecified
+// order. Each entry is a struct whose type is built at run time, so the
+// result can be type asserted like any other:
+//
+//	entries := Entries(map[string]int{"a": 1}).([]struct {
+//		Key   string
+//		Value int
+//	})
+func Entries(m interface{}) interface{} {
+	chk := ty.Check(
+		new(func(map[ty.A]ty.B) []struct {
+			Key   ty.A
+			Value ty.B
+		}),
+		m)
+	vm := chk.Args[0]
+
+	tentry := entryOf(vm.Type().Key(), vm.Type().Elem())
+	ventries := reflect.MakeSlice(reflect.SliceOf(tentry), 0, vm.Len())
+	for iter := vm.MapRange(); iter.Next(); {
+		ventry := reflect.New(tentry).Elem()
+		ventry.Field(0).Set(iter.Key())
+		ventry.Field(1).Set(iter.Value())
+		ventries = reflect.Append(ventries, ventry)
+	}
+	return verified(chk, ventries.Interface())
+}
+
+// FromEntries has a parametric type:
+//
+//	func FromEntries(entries []struct{ Key K; Value V }) map[K]V
+//
+// FromEntries is the inverse of Entries. It returns a new map with the key
+// and value of each entry. When a key occurs more than once, the value of
+// its last entry is kept.
+//
+// FromEntries panics with a `TypeError` if entries is not a slice of structs
+// with exactly two fields named Key and Value.
+func FromEntries(entries interface{}) interface{} {
+	chk := ty.Check(
+		new(func([]ty.A)),
+		entries)
+	ventries := chk.Args[0]
+
+	tentry := ventries.Type().Elem()
+	if tentry.Kind() != reflect.Struct || tentry.NumField() != 2 ||
+		tentry.Field(0).Name != "Key" || tentry.Field(1).Name != "Value" {
+		panic(ty.TypeError(fmt.Sprintf(
+			"Expected a slice of entries with fields 'Key' and 'Value', "+
+				"but got '%s'.", ventries.Type())))
+	}
+	assertComparable("FromEntries", tentry.Field(0).Type)
+
+	tm := reflect.MapOf(tentry.Field(0).Type, tentry.Field(1).Type)
+	entriesLen := ventries.Len()
+	vm := reflect.MakeMapWithSize(tm, entriesLen)
+	for i := 0; i < entriesLen; i++ {
+		ventry := ventries.Index(i)
+		vm.SetMapIndex(ventry.Field(0), ventry.Field(1))
+	}
+	return vm.Interface()
+}
+
+// SortedKeys has a parametric type:
+//
+//	func SortedKeys(less func(K, K) bool, m map[K]V) []K
+//
+// SortedKeys returns a list of the keys of `m` sorted with `less`. Unlike
+// Keys, the order of the result does not depend on the order of iteration
+// over `m`, which makes it suitable for iterating over a map
+// deterministically.
+func SortedKeys(less, m interface{}) interface{} {
+	chk := ty.Check(
+		new(func(func(ty.A, ty.A) bool, map[ty.A]ty.B) []ty.A),
+		less, m)
+	vless, vm := chk.Args[0], chk.Args[1]
+
+	return verified(chk, QuickSort(vless.Interface(), Keys(vm.Interface())))
+}
+
+// entryOf returns the type `struct { Key K; Value V }`.
+func entryOf(tk, tv reflect.Type) reflect.Type {
+	return reflect.StructOf([]reflect.StructField{
+		{Name: "Key", Type: tk},
+		{Name: "Value", Type: tv},
+	})
+}
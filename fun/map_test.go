@@ -1,6 +1,7 @@
 package fun
 
 import (
+	"strconv"
 	"testing"
 )
 
@@ -23,3 +24,65 @@ func TestValues(t *testing.T) {
 	scmp := func(a, b string) bool { return a < b }
 	assertDeep(t, []string{"a", "b", "c"}, QuickSort(scmp, vals))
 }
+
+func TestMapMerge(t *testing.T) {
+	m1 := map[string]int{"a": 1, "b": 2}
+	m2 := map[string]int{"b": 3, "c": 4}
+	add := func(k string, v1, v2 int) int { return v1 + v2 }
+
+	assertDeep(t, MapMerge(m1, m2, add),
+		map[string]int{"a": 1, "b": 5, "c": 4})
+	assertDeep(t, MapMerge(m1, m2, nil),
+		map[string]int{"a": 1, "b": 3, "c": 4})
+	assertDeep(t, m1, map[string]int{"a": 1, "b": 2})
+}
+
+func TestMapFilter(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	odd := func(k string, v int) bool { return v%2 == 1 }
+	assertDeep(t, MapFilter(odd, m), map[string]int{"a": 1, "c": 3})
+}
+
+func TestMapValues(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2}
+	assertDeep(t, MapValues(strconv.Itoa, m),
+		map[string]string{"a": "1", "b": "2"})
+}
+
+func TestMapKeys(t *testing.T) {
+	m := map[int]string{1: "a", 2: "b"}
+	assertDeep(t, MapKeys(strconv.Itoa, m),
+		map[string]string{"1": "a", "2": "b"})
+}
+
+func TestInvert(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2}
+	assertDeep(t, Invert(m), map[int]string{1: "a", 2: "b"})
+}
+
+func TestEntries(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	entries := Entries(m).([]struct {
+		Key   string
+		Value int
+	})
+	if len(entries) != len(m) {
+		t.Fatalf("Expected %d entries but got %d.", len(m), len(entries))
+	}
+	for _, e := range entries {
+		assertDeep(t, e.Value, m[e.Key])
+	}
+	assertDeep(t, FromEntries(entries), m)
+}
+
+func TestSortedKeys(t *testing.T) {
+	m := map[string]int{"c": 0, "b": 0, "a": 0}
+	scmp := func(a, b string) bool { return a < b }
+	assertDeep(t, SortedKeys(scmp, m), []string{"a", "b", "c"})
+}
+
+func TestMapUntypedNil(t *testing.T) {
+	m := map[string]int{"a": 1}
+	assertDeep(t, MapMerge(nil, m, nil), m)
+	assertDeep(t, MapMerge(m, nil, nil), m)
+}
@@ -1,6 +1,11 @@
 package fun
 
-import "github.com/BurntSushi/ty"
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/BurntSushi/ty"
+)
 
 // MinInt has a parametric type:
 //
@@ -10,7 +15,7 @@ import "github.com/BurntSushi/ty"
 // of length 0, it will return 0
 func MinInt(f, xs interface{}) int64 {
 	chk := ty.Check(
-		new(func(func(ty.A) int64, []ty.A)),
+		new(func(func(ty.A) int64, []ty.A) int64),
 		f, xs)
 	vp, vxs := chk.Args[0], chk.Args[1]
 
@@ -37,7 +42,7 @@ func MinInt(f, xs interface{}) int64 {
 // of length 0, it will return 0
 func MaxInt(f, xs interface{}) int64 {
 	chk := ty.Check(
-		new(func(func(ty.A) int64, []ty.A)),
+		new(func(func(ty.A) int64, []ty.A) int64),
 		f, xs)
 	vp, vxs := chk.Args[0], chk.Args[1]
 
@@ -64,7 +69,7 @@ func MaxInt(f, xs interface{}) int64 {
 // of length 0, it will return 0 and 0
 func MinMaxInt(f, xs interface{}) (int64, int64) {
 	chk := ty.Check(
-		new(func(func(ty.A) int64, []ty.A)),
+		new(func(func(ty.A) int64, []ty.A) (int64, int64)),
 		f, xs)
 	vp, vxs := chk.Args[0], chk.Args[1]
 
@@ -96,7 +101,7 @@ func MinMaxInt(f, xs interface{}) (int64, int64) {
 // of length 0, it will return 0.0
 func MinFloat(f, xs interface{}) float64 {
 	chk := ty.Check(
-		new(func(func(ty.A) float64, []ty.A)),
+		new(func(func(ty.A) float64, []ty.A) float64),
 		f, xs)
 	vp, vxs := chk.Args[0], chk.Args[1]
 
@@ -124,7 +129,7 @@ func MinFloat(f, xs interface{}) float64 {
 // of length 0, it will return 0.0
 func MaxFloat(f, xs interface{}) float64 {
 	chk := ty.Check(
-		new(func(func(ty.A) float64, []ty.A)),
+		new(func(func(ty.A) float64, []ty.A) float64),
 		f, xs)
 	vp, vxs := chk.Args[0], chk.Args[1]
 
@@ -151,7 +156,7 @@ func MaxFloat(f, xs interface{}) float64 {
 // of length 0, it will return 0.0 and 0.0
 func MinMaxFloat(f, xs interface{}) (float64, float64) {
 	chk := ty.Check(
-		new(func(func(ty.A) float64, []ty.A)),
+		new(func(func(ty.A) float64, []ty.A) (float64, float64)),
 		f, xs)
 	vp, vxs := chk.Args[0], chk.Args[1]
 
@@ -182,7 +187,7 @@ func MinMaxFloat(f, xs interface{}) (float64, float64) {
 // SumInt returns the sum of the values returned from f
 func SumInt(f, xs interface{}) int64 {
 	chk := ty.Check(
-		new(func(func(ty.A) int64, []ty.A)),
+		new(func(func(ty.A) int64, []ty.A) int64),
 		f, xs)
 	vp, vxs := chk.Args[0], chk.Args[1]
 
@@ -203,7 +208,7 @@ func SumInt(f, xs interface{}) int64 {
 // SumFloat returns the sum of the values returned from f
 func SumFloat(f, xs interface{}) float64 {
 	chk := ty.Check(
-		new(func(func(ty.A) float64, []ty.A)),
+		new(func(func(ty.A) float64, []ty.A) float64),
 		f, xs)
 	vp, vxs := chk.Args[0], chk.Args[1]
 
@@ -216,3 +221,248 @@ func SumFloat(f, xs interface{}) float64 {
 
 	return sum
 }
+
+// Min has a parametric type:
+//
+//  func Min(f func(A) N, xs []A) (N, bool)
+//
+// Min returns the minimum value returned from f, where N may be any integer,
+// unsigned integer or floating point type. The result has the same type as
+// the values returned from f. If xs is empty, Min returns the zero value of
+// N and false.
+func Min(f, xs interface{}) (interface{}, bool) {
+	chk := ty.Check(
+		new(func(func(ty.A) ty.B, []ty.A) (ty.B, bool)),
+		f, xs)
+	vf, vxs, tn := chk.Args[0], chk.Args[1], chk.Returns[0]
+	assertNumeric(tn)
+
+	_, vmin, ok := minBy(vf, vxs, false)
+	if !ok {
+		zero := zeroValue(tn).Interface()
+		chk.VerifyReturns(zero, false)
+		return zero, false
+	}
+	min := vmin.Interface()
+	chk.VerifyReturns(min, true)
+	return min, true
+}
+
+// Max has a parametric type:
+//
+//  func Max(f func(A) N, xs []A) (N, bool)
+//
+// Max returns the maximum value returned from f, where N may be any integer,
+// unsigned integer or floating point type. The result has the same type as
+// the values returned from f. If xs is empty, Max returns the zero value of
+// N and false.
+func Max(f, xs interface{}) (interface{}, bool) {
+	chk := ty.Check(
+		new(func(func(ty.A) ty.B, []ty.A) (ty.B, bool)),
+		f, xs)
+	vf, vxs, tn := chk.Args[0], chk.Args[1], chk.Returns[0]
+	assertNumeric(tn)
+
+	_, vmax, ok := minBy(vf, vxs, true)
+	if !ok {
+		zero := zeroValue(tn).Interface()
+		chk.VerifyReturns(zero, false)
+		return zero, false
+	}
+	max := vmax.Interface()
+	chk.VerifyReturns(max, true)
+	return max, true
+}
+
+// MinMax has a parametric type:
+//
+//  func MinMax(f func(A) N, xs []A) (N, N, bool)
+//
+// MinMax returns the minimum and maximum values returned from f, where N may
+// be any integer, unsigned integer or floating point type. If xs is empty,
+// MinMax returns the zero value of N twice and false.
+func MinMax(f, xs interface{}) (interface{}, interface{}, bool) {
+	chk := ty.Check(
+		new(func(func(ty.A) ty.B, []ty.A) (ty.B, ty.B, bool)),
+		f, xs)
+	vf, vxs, tn := chk.Args[0], chk.Args[1], chk.Returns[0]
+	assertNumeric(tn)
+
+	xsLen := vxs.Len()
+	if xsLen == 0 {
+		zero := zeroValue(tn).Interface()
+		chk.VerifyReturns(zero, zero, false)
+		return zero, zero, false
+	}
+	vmin := call1(vf, vxs.Index(0))
+	vmax := vmin
+	for i := 1; i < xsLen; i++ {
+		local := call1(vf, vxs.Index(i))
+		if numLess(local, vmin) {
+			vmin = local
+		}
+		if numLess(vmax, local) {
+			vmax = local
+		}
+	}
+	lo, hi := vmin.Interface(), vmax.Interface()
+	chk.VerifyReturns(lo, hi, true)
+	return lo, hi, true
+}
+
+// Sum has a parametric type:
+//
+//  func Sum(f func(A) N, xs []A) N
+//
+// Sum returns the sum of the values returned from f, where N may be any
+// integer, unsigned integer or floating point type. Integer sums wrap around
+// on overflow just like Go's `+`. The sum of an empty list is 0.
+func Sum(f, xs interface{}) interface{} {
+	chk := ty.Check(
+		new(func(func(ty.A) ty.B, []ty.A) ty.B),
+		f, xs)
+	vf, vxs, tn := chk.Args[0], chk.Args[1], chk.Returns[0]
+	assertNumeric(tn)
+
+	xsLen := vxs.Len()
+	vsum := zeroValue(tn)
+	switch {
+	case isInt(tn):
+		var sum int64
+		for i := 0; i < xsLen; i++ {
+			sum += call1(vf, vxs.Index(i)).Int()
+		}
+		vsum.SetInt(sum)
+	case isUint(tn):
+		var sum uint64
+		for i := 0; i < xsLen; i++ {
+			sum += call1(vf, vxs.Index(i)).Uint()
+		}
+		vsum.SetUint(sum)
+	default:
+		var sum float64
+		for i := 0; i < xsLen; i++ {
+			sum += call1(vf, vxs.Index(i)).Float()
+		}
+		vsum.SetFloat(sum)
+	}
+	return verified(chk, vsum.Interface())
+}
+
+// MinBy has a parametric type:
+//
+//  func MinBy(f func(A) N, xs []A) (A, bool)
+//
+// MinBy returns the first element of xs for which f returns the minimum
+// value, where N may be any integer, unsigned integer or floating point
+// type. If xs is empty, MinBy returns the zero value of A and false.
+func MinBy(f, xs interface{}) (interface{}, bool) {
+	chk := ty.Check(
+		new(func(func(ty.A) ty.B, []ty.A) (ty.A, bool)),
+		f, xs)
+	vf, vxs, ta := chk.Args[0], chk.Args[1], chk.Returns[0]
+	assertNumeric(vf.Type().Out(0))
+
+	vx, _, ok := minBy(vf, vxs, false)
+	if !ok {
+		zero := zeroValue(ta).Interface()
+		chk.VerifyReturns(zero, false)
+		return zero, false
+	}
+	x := vx.Interface()
+	chk.VerifyReturns(x, true)
+	return x, true
+}
+
+// MaxBy has a parametric type:
+//
+//  func MaxBy(f func(A) N, xs []A) (A, bool)
+//
+// MaxBy returns the first element of xs for which f returns the maximum
+// value, where N may be any integer, unsigned integer or floating point
+// type. If xs is empty, MaxBy returns the zero value of A and false.
+func MaxBy(f, xs interface{}) (interface{}, bool) {
+	chk := ty.Check(
+		new(func(func(ty.A) ty.B, []ty.A) (ty.A, bool)),
+		f, xs)
+	vf, vxs, ta := chk.Args[0], chk.Args[1], chk.Returns[0]
+	assertNumeric(vf.Type().Out(0))
+
+	vx, _, ok := minBy(vf, vxs, true)
+	if !ok {
+		zero := zeroValue(ta).Interface()
+		chk.VerifyReturns(zero, false)
+		return zero, false
+	}
+	x := vx.Interface()
+	chk.VerifyReturns(x, true)
+	return x, true
+}
+
+// minBy returns the first element of `vxs` for which `vf` returns the
+// minimum value (or maximum value if `max` is true) along with that value.
+// It returns false if `vxs` is empty.
+func minBy(vf, vxs reflect.Value, max bool) (
+	reflect.Value, reflect.Value, bool) {
+
+	xsLen := vxs.Len()
+	if xsLen == 0 {
+		return reflect.Value{}, reflect.Value{}, false
+	}
+	vbest, vn := vxs.Index(0), call1(vf, vxs.Index(0))
+	for i := 1; i < xsLen; i++ {
+		vx := vxs.Index(i)
+		local := call1(vf, vx)
+		if (!max && numLess(local, vn)) || (max && numLess(vn, local)) {
+			vbest, vn = vx, local
+		}
+	}
+	return vbest, vn, true
+}
+
+// numLess returns true if the number `a` is less than the number `b`.
+// They must have the same numeric type.
+func numLess(a, b reflect.Value) bool {
+	switch {
+	case isInt(a.Type()):
+		return a.Int() < b.Int()
+	case isUint(a.Type()):
+		return a.Uint() < b.Uint()
+	}
+	return a.Float() < b.Float()
+}
+
+// assertNumeric panics with a `TypeError` if `t` is not an integer, unsigned
+// integer or floating point type.
+func assertNumeric(t reflect.Type) {
+	if !isInt(t) && !isUint(t) && !isFloat(t) {
+		panic(ty.TypeError(fmt.Sprintf(
+			"Expected a numeric type, but got '%s'.", t)))
+	}
+}
+
+func isInt(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isUint(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+func isFloat(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -1,6 +1,10 @@
 package fun
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/BurntSushi/ty"
+)
 
 func TestMinInt(t *testing.T) {
 	square := func(x int64) int64 { return x * x }
@@ -117,3 +121,87 @@ func TestSumFloat(t *testing.T) {
 		t.Fatalf("TestSumFloat: 0 should be the sum of no values, was %v", sum)
 	}
 }
+
+func TestMin(t *testing.T) {
+	neg := func(x int32) int32 { return -x }
+	min, ok := Min(neg, []int32{1, 2, 3})
+	assertDeep(t, min, int32(-3))
+	assertDeep(t, ok, true)
+
+	min, ok = Min(neg, []int32{})
+	assertDeep(t, min, int32(0))
+	assertDeep(t, ok, false)
+
+	id := func(x uint8) uint8 { return x }
+	min, ok = Min(id, []uint8{3, 0, 2})
+	assertDeep(t, min, uint8(0))
+	assertDeep(t, ok, true)
+}
+
+func TestMax(t *testing.T) {
+	half := func(x int) float32 { return float32(x) / 2 }
+	max, ok := Max(half, []int{1, 5, 3})
+	assertDeep(t, max, float32(2.5))
+	assertDeep(t, ok, true)
+
+	max, ok = Max(half, []int{})
+	assertDeep(t, max, float32(0))
+	assertDeep(t, ok, false)
+}
+
+func TestMinMax(t *testing.T) {
+	id := func(x uint16) uint16 { return x }
+	min, max, ok := MinMax(id, []uint16{4, 1, 9, 3})
+	assertDeep(t, min, uint16(1))
+	assertDeep(t, max, uint16(9))
+	assertDeep(t, ok, true)
+
+	min, max, ok = MinMax(id, []uint16{})
+	assertDeep(t, min, uint16(0))
+	assertDeep(t, max, uint16(0))
+	assertDeep(t, ok, false)
+}
+
+func TestSum(t *testing.T) {
+	square := func(x int8) int8 { return x * x }
+	assertDeep(t, Sum(square, []int8{1, 2, 3}), int8(14))
+	assertDeep(t, Sum(square, []int8{}), int8(0))
+
+	length := func(s string) uint { return uint(len(s)) }
+	assertDeep(t, Sum(length, []string{"a", "bc"}), uint(3))
+
+	half := func(x float32) float32 { return x / 2 }
+	assertDeep(t, Sum(half, []float32{1, 2}), float32(1.5))
+}
+
+func TestMinMaxBy(t *testing.T) {
+	type album struct {
+		title string
+		year  int16
+	}
+	albums := []album{
+		{"Darkness", 1978}, {"WIESS", 1973}, {"Greetings", 1973},
+	}
+	year := func(a album) int16 { return a.year }
+
+	oldest, ok := MinBy(year, albums)
+	assertDeep(t, oldest, album{"WIESS", 1973})
+	assertDeep(t, ok, true)
+
+	newest, ok := MaxBy(year, albums)
+	assertDeep(t, newest, album{"Darkness", 1978})
+	assertDeep(t, ok, true)
+
+	none, ok := MinBy(year, []album{})
+	assertDeep(t, none, album{})
+	assertDeep(t, ok, false)
+}
+
+func TestSumNotNumeric(t *testing.T) {
+	defer func() {
+		if _, ok := recover().(ty.TypeError); !ok {
+			t.Fatal("summing strings should panic with a TypeError")
+		}
+	}()
+	Sum(func(s string) string { return s }, []string{"a"})
+}
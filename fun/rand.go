@@ -1,8 +1,12 @@
 package fun
 
 import (
+	"container/heap"
+	"fmt"
+	"math"
 	"math/rand"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/BurntSushi/ty"
@@ -11,7 +15,43 @@ import (
 var randNumGen *rand.Rand
 
 func init() {
-	randNumGen = rand.New(rand.NewSource(time.Now().UnixNano()))
+	randNumGen = rand.New(&lockedSource{
+		src: rand.NewSource(time.Now().UnixNano()).(rand.Source64),
+	})
+}
+
+// Seed sets the seed of the default random number generator used by
+// `Shuffle`, `Sample` and friends, which is otherwise seeded once at program
+// initialization with the current time. Seeding with a fixed value makes
+// their results reproducible, e.g., in tests.
+//
+// The default random number generator is safe for concurrent use.
+func Seed(seed int64) {
+	randNumGen.Seed(seed)
+}
+
+// lockedSource is a random number source that is safe for concurrent use.
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source64
+}
+
+func (ls *lockedSource) Int63() int64 {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	return ls.src.Int63()
+}
+
+func (ls *lockedSource) Uint64() uint64 {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	return ls.src.Uint64()
+}
+
+func (ls *lockedSource) Seed(seed int64) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	ls.src.Seed(seed)
 }
 
 // ShuffleGen has a parametric type:
@@ -40,7 +80,7 @@ func ShuffleGen(xs interface{}, rng *rand.Rand) {
 //	func Shuffle(xs []A)
 //
 // Shuffle shuffles `xs` in place using a default random number
-// generator seeded once at program initialization.
+// generator. (See `Seed`.)
 func Shuffle(xs interface{}) {
 	ShuffleGen(xs, randNumGen)
 }
@@ -50,8 +90,7 @@ func Shuffle(xs interface{}) {
 //	func Sample(population []A, n int) []A
 //
 // Sample returns a random sample of size `n` from a list
-// `population` using a default random number generator seeded once at
-// program initialization.
+// `population` using a default random number generator. (See `Seed`.)
 // All elements in `population` have an equal chance of being selected.
 // If `n` is greater than the size of `population`, then `n` is set to
 // the size of the population.
@@ -68,6 +107,10 @@ func Sample(population interface{}, n int) interface{} {
 // All elements in `population` have an equal chance of being selected.
 // If `n` is greater than the size of `population`, then `n` is set to
 // the size of the population.
+//
+// SampleGen runs in O(n) time rather than shuffling the whole population.
+// This changed the sample drawn for a given `rng` state, so samples taken
+// with a fixed seed differ from those of earlier versions of this package.
 func SampleGen(population interface{}, n int, rng *rand.Rand) interface{} {
 	chk := ty.Check(
 		new(func([]ty.A, int, *rand.Rand) []ty.A),
@@ -76,19 +119,231 @@ func SampleGen(population interface{}, n int, rng *rand.Rand) interface{} {
 
 	popLen := rpop.Len()
 	if n == 0 {
-		return reflect.MakeSlice(tsamp, 0, 0).Interface()
+		return verified(chk, reflect.MakeSlice(tsamp, 0, 0).Interface())
 	}
 	if n > popLen {
 		n = popLen
 	}
 
-	// TODO(burntsushi): Implement an algorithm that doesn't depend on
-	// the size of the population.
+	// A Fisher-Yates shuffle that stops after `n` steps. Rather than
+	// shuffling a permutation of the entire population, only the positions
+	// that have been swapped are remembered.
+	rsamp := reflect.MakeSlice(tsamp, n, n)
+	swapped := make(map[int]int, n)
+	for i := 0; i < n; i++ {
+		j := i + rng.Intn(popLen-i)
+		ith, jth := swappedIndex(swapped, i), swappedIndex(swapped, j)
+		swapped[j] = ith
+		rsamp.Index(i).Set(rpop.Index(jth))
+	}
+	return verified(chk, rsamp.Interface())
+}
+
+// swappedIndex returns the index of the population currently at position
+// `i` of a partial Fisher-Yates shuffle.
+func swappedIndex(swapped map[int]int, i int) int {
+	if j, ok := swapped[i]; ok {
+		return j
+	}
+	return i
+}
 
+// SampleReplace has a parametric type:
+//
+//	func SampleReplace(population []A, n int) []A
+//
+// SampleReplace is just like `SampleReplaceGen`, except it uses a default
+// random number generator. (See `Seed`.)
+func SampleReplace(population interface{}, n int) interface{} {
+	return SampleReplaceGen(population, n, randNumGen)
+}
+
+// SampleReplaceGen has a parametric type:
+//
+//	func SampleReplaceGen(population []A, n int, rng *rand.Rand) []A
+//
+// SampleReplaceGen returns a random sample of size `n` from a list
+// `population` with replacement using a given random number generator `rng`.
+// That is, each element of the sample is chosen independently, so the same
+// element may be chosen more than once and `n` may be greater than the size
+// of the population. If the population is empty, the sample is empty.
+func SampleReplaceGen(
+	population interface{}, n int, rng *rand.Rand) interface{} {
+
+	chk := ty.Check(
+		new(func([]ty.A, int, *rand.Rand) []ty.A),
+		population, n, rng)
+	rpop, tsamp := chk.Args[0], chk.Returns[0]
+
+	popLen := rpop.Len()
+	if popLen == 0 {
+		return verified(chk, reflect.MakeSlice(tsamp, 0, 0).Interface())
+	}
 	rsamp := reflect.MakeSlice(tsamp, n, n)
-	choices := rng.Perm(popLen)
 	for i := 0; i < n; i++ {
-		rsamp.Index(i).Set(rpop.Index(choices[i]))
+		rsamp.Index(i).Set(rpop.Index(rng.Intn(popLen)))
+	}
+	return verified(chk, rsamp.Interface())
+}
+
+// SampleWeighted has a parametric type:
+//
+//	func SampleWeighted(weight func(A) float64, population []A, n int) []A
+//
+// SampleWeighted is just like `SampleWeightedGen`, except it uses a default
+// random number generator. (See `Seed`.)
+func SampleWeighted(weight, population interface{}, n int) interface{} {
+	return SampleWeightedGen(weight, population, n, randNumGen)
+}
+
+// SampleWeightedGen has a parametric type:
+//
+//	func SampleWeightedGen(
+//		weight func(A) float64, population []A, n int, rng *rand.Rand) []A
+//
+// SampleWeightedGen returns a random sample of size `n` from a list
+// `population` without replacement using a given random number generator
+// `rng`, where the chance of an element being selected is proportional to
+// `weight` applied to that element.
+//
+// Elements with a weight of zero are never selected, so the sample is
+// smaller than `n` if fewer than `n` elements have a positive weight.
+// SampleWeightedGen panics if any weight is negative or NaN.
+//
+// The sample is computed in a single pass over `population` using the
+// algorithm of Efraimidis and Spirakis, and is in no particular order.
+func SampleWeightedGen(
+	weight, population interface{}, n int, rng *rand.Rand) interface{} {
+
+	chk := ty.Check(
+		new(func(func(ty.A) float64, []ty.A, int, *rand.Rand) []ty.A),
+		weight, population, n, rng)
+	vweight, rpop, tsamp := chk.Args[0], chk.Args[1], chk.Returns[0]
+
+	// Every element gets the key `-ln(u) / w` where `u` is uniform in (0, 1]
+	// and the sample is the `n` elements with the smallest keys. A max-heap of
+	// the best `n` keys is maintained while scanning the population.
+	best := &weightedHeap{}
+	popLen := rpop.Len()
+	for i := 0; i < popLen && n > 0; i++ {
+		w := call1(vweight, rpop.Index(i)).Float()
+		if math.IsNaN(w) {
+			panic(fmt.Sprintf("weight of element %d is not a number", i))
+		}
+		if w < 0 {
+			panic(fmt.Sprintf("weight %v of element %d is negative", w, i))
+		}
+		if w == 0 {
+			continue
+		}
+		key := -math.Log(1-rng.Float64()) / w
+		if best.Len() < n {
+			heap.Push(best, weighted{i, key})
+		} else if key < (*best)[0].key {
+			(*best)[0] = weighted{i, key}
+			heap.Fix(best, 0)
+		}
+	}
+
+	rsamp := reflect.MakeSlice(tsamp, best.Len(), best.Len())
+	for i, w := range *best {
+		rsamp.Index(i).Set(rpop.Index(w.index))
+	}
+	return verified(chk, rsamp.Interface())
+}
+
+type weighted struct {
+	index int
+	key   float64
+}
+
+// weightedHeap is a max-heap of elements ordered by their keys.
+type weightedHeap []weighted
+
+func (h weightedHeap) Len() int            { return len(h) }
+func (h weightedHeap) Less(i, j int) bool  { return h[i].key > h[j].key }
+func (h weightedHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *weightedHeap) Push(x interface{}) { *h = append(*h, x.(weighted)) }
+
+func (h *weightedHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// SampleChan has a parametric type:
+//
+//	func SampleChan(ch <-chan A, n int) []A
+//
+// SampleChan is just like `SampleChanGen`, except it uses a default random
+// number generator. (See `Seed`.)
+func SampleChan(ch interface{}, n int) interface{} {
+	return SampleChanGen(ch, n, randNumGen)
+}
+
+// SampleChanGen has a parametric type:
+//
+//	func SampleChanGen(ch <-chan A, n int, rng *rand.Rand) []A
+//
+// SampleChanGen receives every value from the channel `ch` until it is
+// closed and returns a random sample of size `n` of those values using a
+// given random number generator `rng`. All values have an equal chance of
+// being selected. If fewer than `n` values are received, then all of them
+// are returned. `ch` may also be a bidirectional channel.
+//
+// Only the sample is kept in memory. It is computed with reservoir sampling
+// (Li's "Algorithm L"), which uses a number of random numbers proportional
+// to the size of the sample rather than the number of values received.
+// The sample is in no particular order.
+func SampleChanGen(ch interface{}, n int, rng *rand.Rand) interface{} {
+	chk := ty.Check(
+		new(func(<-chan ty.A, int, *rand.Rand) []ty.A),
+		recvChan(ch), n, rng)
+	rch, tsamp := chk.Args[0], chk.Returns[0]
+
+	if n <= 0 {
+		// Drain the channel anyway, so that senders are not blocked forever.
+		for _, ok := rch.Recv(); ok; _, ok = rch.Recv() {
+		}
+		return verified(chk, reflect.MakeSlice(tsamp, 0, 0).Interface())
+	}
+	rsamp := reflect.MakeSlice(tsamp, 0, n)
+
+	// Fill the reservoir.
+	for rsamp.Len() < n {
+		rv, ok := rch.Recv()
+		if !ok {
+			return verified(chk, rsamp.Interface())
+		}
+		rsamp = reflect.Append(rsamp, rv)
+	}
+
+	// uniform returns a random number in the open interval (0, 1).
+	uniform := func() float64 {
+		for {
+			if u := rng.Float64(); u > 0 {
+				return u
+			}
+		}
+	}
+
+	// Instead of generating a random number for every value, skip ahead
+	// a geometrically distributed number of values to the next value that
+	// replaces an element of the reservoir.
+	w := math.Exp(math.Log(uniform()) / float64(n))
+	for {
+		skip := int64(math.Floor(math.Log(uniform()) / math.Log(1-w)))
+		for ; skip > 0; skip-- {
+			if _, ok := rch.Recv(); !ok {
+				return verified(chk, rsamp.Interface())
+			}
+		}
+		rv, ok := rch.Recv()
+		if !ok {
+			return verified(chk, rsamp.Interface())
+		}
+		rsamp.Index(rng.Intn(n)).Set(rv)
+		w *= math.Exp(math.Log(uniform()) / float64(n))
 	}
-	return rsamp.Interface()
 }
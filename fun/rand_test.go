@@ -1,6 +1,7 @@
 package fun
 
 import (
+	"math"
 	"math/rand"
 	"testing"
 )
@@ -17,7 +18,122 @@ func TestSample(t *testing.T) {
 	nums := Range(0, 100)
 	sample := SampleGen(nums, 3, rng).([]int)
 
-	assertDeep(t, Set([]int{35, 40, 50}), Set(sample))
+	assertDeep(t, Set([]int{23, 37, 74}), Set(sample))
+}
+
+func TestSeed(t *testing.T) {
+	Seed(42)
+	first := Sample(Range(0, 100), 10)
+	Seed(42)
+	assertDeep(t, first, Sample(Range(0, 100), 10))
+}
+
+func TestSampleSize(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	nums := Range(0, 100)
+
+	sample := SampleGen(nums, 100, rng).([]int)
+	assertDeep(t, Set(sample), Set(nums))
+
+	sample = SampleGen(nums, 1000, rng).([]int)
+	assertDeep(t, Set(sample), Set(nums))
+
+	assertDeep(t, SampleGen(nums, 0, rng), []int{})
+}
+
+func TestSampleReplace(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	sample := SampleReplaceGen([]string{"a", "b"}, 50, rng).([]string)
+	assertDeep(t, len(sample), 50)
+	assertDeep(t, Set(sample), map[string]bool{"a": true, "b": true})
+
+	assertDeep(t, SampleReplaceGen([]string{}, 5, rng), []string{})
+}
+
+func TestSampleWeighted(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	weight := func(n int) float64 { return float64(n % 3) }
+	sample := SampleWeightedGen(weight, Range(0, 9), 100, rng).([]int)
+	assertDeep(t, Set(sample), Set([]int{1, 2, 4, 5, 7, 8}))
+
+	// An element with overwhelming weight is practically always chosen.
+	heavy := func(n int) float64 {
+		if n == 7 {
+			return 1e12
+		}
+		return 1
+	}
+	for i := 0; i < 10; i++ {
+		sample = SampleWeightedGen(heavy, Range(0, 100), 1, rng).([]int)
+		assertDeep(t, sample, []int{7})
+	}
+}
+
+func TestSampleWeightedInvalid(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	tests := []struct {
+		w    float64
+		want string
+	}{
+		{-1, "weight -1 of element 0 is negative"},
+		{math.NaN(), "weight of element 0 is not a number"},
+	}
+	for _, test := range tests {
+		w, want := test.w, test.want
+		func() {
+			defer func() {
+				if got := recover(); got != want {
+					t.Fatalf("Expected a panic with %q but got %v", want, got)
+				}
+			}()
+			weight := func(n int) float64 { return w }
+			SampleWeightedGen(weight, Range(0, 3), 1, rng)
+		}()
+	}
+}
+
+func TestSampleChan(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	counts := make(map[int]int)
+	for i := 0; i < 1000; i++ {
+		ch := make(chan int)
+		go func() {
+			for _, n := range Range(0, 10) {
+				ch <- n
+			}
+			close(ch)
+		}()
+
+		sample := SampleChanGen(ch, 3, rng).([]int)
+		assertDeep(t, len(Set(sample).(map[int]bool)), 3)
+		for _, n := range sample {
+			counts[n]++
+		}
+	}
+
+	// Each value should be chosen about 300 times.
+	for n := 0; n < 10; n++ {
+		if counts[n] < 200 || counts[n] > 400 {
+			t.Fatalf("%d was sampled %d times out of 1000", n, counts[n])
+		}
+	}
+
+	ch := make(chan int, 2)
+	ch <- 1
+	ch <- 2
+	close(ch)
+	assertDeep(t, SampleChan((<-chan int)(ch), 5), []int{1, 2})
+
+	// A sample of no values still receives every value.
+	for _, n := range []int{0, -1} {
+		ch := make(chan int)
+		go func() {
+			ch <- 1
+			ch <- 2
+			close(ch)
+		}()
+		assertDeep(t, SampleChan(ch, n), []int{})
+	}
 }
 
 func BenchmarkShuffle(b *testing.B) {
@@ -1,6 +1,7 @@
 package fun
 
 import (
+	"fmt"
 	"reflect"
 
 	"github.com/BurntSushi/ty"
@@ -23,7 +24,36 @@ func Set(xs interface{}) interface{} {
 	for i := 0; i < xsLen; i++ {
 		vset.SetMapIndex(vxs.Index(i), vtrue)
 	}
-	return vset.Interface()
+	return verified(chk, vset.Interface())
+}
+
+// SetBy has a parametric type:
+//
+//	func SetBy(xs []A) []A
+//
+// SetBy is just like `Set`, except `A` may be any type, including types
+// that cannot be map keys such as slices, maps and functions. Elements are
+// compared with `Equal`, and since the set cannot be a `map[A]bool`, it is
+// returned as a list of the distinct elements of `xs` in the order that
+// they first occur.
+func SetBy(xs interface{}) interface{} {
+	chk := ty.Check(
+		new(func([]ty.A) []ty.A),
+		xs)
+	vxs, tset := chk.Args[0], chk.Returns[0]
+
+	seen := newDeepMap()
+	vtrue := reflect.ValueOf(true)
+	xsLen := vxs.Len()
+	vset := reflect.MakeSlice(tset, 0, xsLen)
+	for i := 0; i < xsLen; i++ {
+		vx := vxs.Index(i)
+		if _, ok := seen.get(vx); !ok {
+			seen.put(vx, vtrue)
+			vset = reflect.Append(vset, vx)
+		}
+	}
+	return verified(chk, vset.Interface())
 }
 
 // Union has a parametric type:
@@ -46,7 +76,7 @@ func Union(a, b interface{}) interface{} {
 	for _, vkey := range vb.MapKeys() {
 		vc.SetMapIndex(vkey, vtrue)
 	}
-	return vc.Interface()
+	return verified(chk, vc.Interface())
 }
 
 // Intersection has a parametric type:
@@ -73,7 +103,7 @@ func Intersection(a, b interface{}) interface{} {
 			vc.SetMapIndex(vkey, vtrue)
 		}
 	}
-	return vc.Interface()
+	return verified(chk, vc.Interface())
 }
 
 // Difference has a parametric type:
@@ -95,5 +125,200 @@ func Difference(a, b interface{}) interface{} {
 			vc.SetMapIndex(vkey, vtrue)
 		}
 	}
-	return vc.Interface()
+	return verified(chk, vc.Interface())
+}
+
+// Uniq has a parametric type:
+//
+//	func Uniq(xs []A) []A
+//
+// Uniq returns the distinct elements of `xs` in the order that they first
+// occur. Elements are distinct in the same sense as they are in `Set`, so
+// `Set(Uniq(xs))` is equal to `Set(xs)`. `A` must be a valid map key type;
+// use `SetBy` for other types.
+func Uniq(xs interface{}) interface{} {
+	chk := ty.Check(
+		new(func([]ty.A) []ty.A),
+		xs)
+	vxs, tys := chk.Args[0], chk.Returns[0]
+	assertComparable("Uniq", tys.Elem())
+
+	return verified(chk, uniqBy(vxs, tys, func(vx reflect.Value) reflect.Value {
+		return vx
+	}).Interface())
+}
+
+// UniqBy has a parametric type:
+//
+//	func UniqBy(key func(A) K, xs []A) []A
+//
+// UniqBy is just like `Uniq`, except two elements are the same when `key`
+// returns the same value for both of them. The first element with each key
+// is kept. `K` must be a valid map key type.
+func UniqBy(key, xs interface{}) interface{} {
+	chk := ty.Check(
+		new(func(func(ty.A) ty.B, []ty.A) []ty.A),
+		key, xs)
+	vkey, vxs, tys := chk.Args[0], chk.Args[1], chk.Returns[0]
+	assertComparable("UniqBy", vkey.Type().Out(0))
+
+	return verified(chk, uniqBy(vxs, tys, func(vx reflect.Value) reflect.Value {
+		return call1(vkey, vx)
+	}).Interface())
+}
+
+// Frequencies has a parametric type:
+//
+//	func Frequencies(xs []A) map[A]int
+//
+// Frequencies returns the number of times each distinct element occurs in
+// `xs`. The keys of the result are exactly the elements of `Set(xs)`.
+func Frequencies(xs interface{}) interface{} {
+	chk := ty.Check(
+		new(func([]ty.A)),
+		xs)
+	vxs := chk.Args[0]
+	telem := vxs.Type().Elem()
+	assertComparable("Frequencies", telem)
+
+	return countBy(vxs, telem, func(vx reflect.Value) reflect.Value {
+		return vx
+	}).Interface()
+}
+
+// CountBy has a parametric type:
+//
+//	func CountBy(f func(A) B, xs []A) map[B]int
+//
+// CountBy returns the number of elements in `xs` for which `f` returns each
+// distinct value. `B` must be a valid map key type.
+func CountBy(f, xs interface{}) interface{} {
+	chk := ty.Check(
+		new(func(func(ty.A) ty.B, []ty.A)),
+		f, xs)
+	vf, vxs := chk.Args[0], chk.Args[1]
+	tkey := vf.Type().Out(0)
+	assertComparable("CountBy", tkey)
+
+	return countBy(vxs, tkey, func(vx reflect.Value) reflect.Value {
+		return call1(vf, vx)
+	}).Interface()
+}
+
+// Duplicates has a parametric type:
+//
+//	func Duplicates(xs []A) []A
+//
+// Duplicates returns the elements that occur more than once in `xs`. Each
+// is returned once, in the order that they first occur. `A` must be a valid
+// map key type.
+func Duplicates(xs interface{}) interface{} {
+	chk := ty.Check(
+		new(func([]ty.A) []ty.A),
+		xs)
+	vxs, tys := chk.Args[0], chk.Returns[0]
+	assertComparable("Duplicates", tys.Elem())
+
+	// The count of each element is 0 once it has been added to the result.
+	vcounts := reflect.MakeMap(reflect.MapOf(tys.Elem(), reflect.TypeOf(0)))
+	xsLen := vxs.Len()
+	for i := 0; i < xsLen; i++ {
+		vx := vxs.Index(i)
+		vcount := vcounts.MapIndex(vx)
+		if !vcount.IsValid() {
+			vcounts.SetMapIndex(vx, reflect.ValueOf(1))
+		} else if vcount.Int() > 0 {
+			vcounts.SetMapIndex(vx, reflect.ValueOf(int(vcount.Int())+1))
+		}
+	}
+
+	vys := reflect.MakeSlice(tys, 0, 0)
+	for i := 0; i < xsLen; i++ {
+		vx := vxs.Index(i)
+		if vcounts.MapIndex(vx).Int() > 1 {
+			vys = reflect.Append(vys, vx)
+			vcounts.SetMapIndex(vx, reflect.ValueOf(0))
+		}
+	}
+	return verified(chk, vys.Interface())
+}
+
+// Compact has a parametric type:
+//
+//	func Compact(xs []A) []A
+//
+// Compact returns a new list with every run of consecutive equal elements in
+// `xs` replaced by its first element. Elements are compared with `==`, so
+// `A` must be comparable. If `xs` is sorted, Compact is equivalent to `Uniq`.
+func Compact(xs interface{}) interface{} {
+	chk := ty.Check(
+		new(func([]ty.A) []ty.A),
+		xs)
+	vxs, tys := chk.Args[0], chk.Returns[0]
+	assertComparable("Compact", tys.Elem())
+
+	xsLen := vxs.Len()
+	vys := reflect.MakeSlice(tys, 0, xsLen)
+	for i := 0; i < xsLen; i++ {
+		vx := vxs.Index(i)
+		if i == 0 || !vx.Equal(vxs.Index(i-1)) {
+			vys = reflect.Append(vys, vx)
+		}
+	}
+	return verified(chk, vys.Interface())
+}
+
+// uniqBy returns a list of type `tys` with the first element of `vxs` for
+// each distinct value returned by `key`.
+func uniqBy(
+	vxs reflect.Value,
+	tys reflect.Type,
+	key func(reflect.Value) reflect.Value,
+) reflect.Value {
+	var vseen reflect.Value
+	vtrue := reflect.ValueOf(true)
+	xsLen := vxs.Len()
+	vys := reflect.MakeSlice(tys, 0, xsLen)
+	for i := 0; i < xsLen; i++ {
+		vx := vxs.Index(i)
+		vk := key(vx)
+		if !vseen.IsValid() {
+			vseen = reflect.MakeMap(reflect.MapOf(vk.Type(), vtrue.Type()))
+		}
+		if !vseen.MapIndex(vk).IsValid() {
+			vseen.SetMapIndex(vk, vtrue)
+			vys = reflect.Append(vys, vx)
+		}
+	}
+	return vys
+}
+
+// countBy returns a map from each distinct value returned by `key` for the
+// elements of `vxs` to the number of times it was returned.
+func countBy(
+	vxs reflect.Value,
+	tkey reflect.Type,
+	key func(reflect.Value) reflect.Value,
+) reflect.Value {
+	vcounts := reflect.MakeMap(reflect.MapOf(tkey, reflect.TypeOf(0)))
+	xsLen := vxs.Len()
+	for i := 0; i < xsLen; i++ {
+		vk := key(vxs.Index(i))
+		n := 0
+		if vcount := vcounts.MapIndex(vk); vcount.IsValid() {
+			n = int(vcount.Int())
+		}
+		vcounts.SetMapIndex(vk, reflect.ValueOf(n+1))
+	}
+	return vcounts
+}
+
+// assertComparable panics with a `TypeError` if values of type `t` cannot
+// be used as map keys.
+func assertComparable(fname string, t reflect.Type) {
+	if !t.Comparable() {
+		panic(ty.TypeError(fmt.Sprintf(
+			"%s needs a type that can be a map key, but '%s' cannot.",
+			fname, t)))
+	}
 }
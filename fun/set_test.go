@@ -2,6 +2,8 @@ package fun
 
 import (
 	"testing"
+
+	"github.com/BurntSushi/ty"
 )
 
 func TestSet(t *testing.T) {
@@ -82,3 +84,59 @@ func TestDifference(t *testing.T) {
 		"seger":       true,
 	})
 }
+
+func TestSetBy(t *testing.T) {
+	xs := [][]int{{1, 2}, {3}, {1, 2}, nil, {3}, {}}
+	set := SetBy(xs).([][]int)
+
+	assertDeep(t, set, [][]int{{1, 2}, {3}, nil})
+}
+
+func TestUniq(t *testing.T) {
+	a := []string{"andrew", "plato", "andrew", "cauchy", "cauchy", "andrew"}
+	assertDeep(t, Uniq(a), []string{"andrew", "plato", "cauchy"})
+	assertDeep(t, Set(Uniq(a)), Set(a))
+	assertDeep(t, Uniq([]int{}), []int{})
+
+	defer func() {
+		if _, ok := recover().(ty.TypeError); !ok {
+			t.Fatal("Uniq of a non-comparable type did not panic")
+		}
+	}()
+	Uniq([][]int{{1}, {1}})
+}
+
+func TestUniqBy(t *testing.T) {
+	a := []string{"andrew", "plato", "amy", "cauchy", "pascal"}
+	first := func(s string) byte { return s[0] }
+	assertDeep(t, UniqBy(first, a), []string{"andrew", "plato", "cauchy"})
+}
+
+func TestFrequencies(t *testing.T) {
+	a := []string{"andrew", "plato", "andrew", "cauchy", "cauchy", "andrew"}
+	freqs := Frequencies(a).(map[string]int)
+	assertDeep(t, freqs, map[string]int{
+		"andrew": 3,
+		"plato":  1,
+		"cauchy": 2,
+	})
+	assertDeep(t, Set(Keys(freqs)), Set(a))
+}
+
+func TestCountBy(t *testing.T) {
+	a := []string{"andrew", "plato", "andrew", "cauchy", "amy"}
+	length := func(s string) int { return len(s) }
+	assertDeep(t, CountBy(length, a), map[int]int{6: 3, 5: 1, 3: 1})
+}
+
+func TestDuplicates(t *testing.T) {
+	a := []int{4, 1, 2, 1, 4, 3, 4, 2}
+	assertDeep(t, Duplicates(a), []int{4, 1, 2})
+	assertDeep(t, Duplicates([]int{1, 2, 3}), []int{})
+}
+
+func TestCompact(t *testing.T) {
+	a := []int{1, 1, 2, 3, 3, 3, 1, 2, 2}
+	assertDeep(t, Compact(a), []int{1, 2, 3, 1, 2})
+	assertDeep(t, Compact([]int{}), []int{})
+}
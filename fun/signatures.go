@@ -0,0 +1,185 @@
+package fun
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/BurntSushi/ty"
+)
+
+// The parametric types of the functions in this package are registered with
+// `ty.RegisterSignature`, qualified by the package name (e.g., `fun.Map`),
+// so that tools can list them and type errors can print them. They are the
+// types given in the documentation of each function, with the type
+// variables `K`, `V`, `J`, `W` and `N` written as `A`, `B`, `C`, `C` and
+// `B`, respectively, in the same way as their implementations check them.
+//
+// `Pipe`, `Partial` and `ZipAll` are not registered, since their parametric
+// types depend on the number of arguments they are given.
+func init() {
+	for name, sig := range signatures {
+		ty.RegisterSignature("fun."+name, sig)
+	}
+}
+
+var signatures = map[string]interface{}{
+	// chan.go
+	"AsyncChan": new(func(*chan ty.A) (chan<- ty.A, <-chan ty.A)),
+
+	// cycle.go
+	"CycleEach": new(func(func(ty.A), []ty.A, int)),
+	"CycleMap":  new(func(func(ty.A) ty.B, []ty.A, int) []ty.B),
+
+	// equal.go
+	"Equal":    new(func(ty.A, ty.A) bool),
+	"Compare":  new(func(ty.A, ty.A) int),
+	"DeepHash": new(func(ty.A) uint64),
+
+	// func.go
+	"Memo":   new(func(func(ty.A) ty.B) func(ty.A) ty.B),
+	"MemoBy": new(func(func(ty.A) ty.B) func(ty.A) ty.B),
+
+	// func_combinators.go
+	"Compose":  new(func(func(ty.B) ty.C, func(ty.A) ty.B) func(ty.A) ty.C),
+	"Curry2":   new(func(func(ty.A, ty.B) ty.C) func(ty.A) func(ty.B) ty.C),
+	"Uncurry2": new(func(func(ty.A) func(ty.B) ty.C) func(ty.A, ty.B) ty.C),
+	"Flip":     new(func(func(ty.A, ty.B) ty.C) func(ty.B, ty.A) ty.C),
+	"Once":     new(func(ty.F) ty.F),
+	"Debounce": new(func(ty.F, time.Duration) ty.F),
+	"Throttle": new(func(ty.F, time.Duration) ty.F),
+	"Retry":    new(func(ty.F, RetryPolicy) ty.F),
+
+	// list.go
+	"Map":           new(func(func(ty.A) ty.B, []ty.A) []ty.B),
+	"MapIndexed":    new(func(func(int, ty.A) ty.B, []ty.A) []ty.B),
+	"FlatMap":       new(func(func(ty.A) []ty.B, []ty.A) []ty.B),
+	"Unfold":        new(func(func(ty.B) (ty.A, ty.B, bool), ty.B) []ty.A),
+	"Filter":        new(func(func(ty.A) bool, []ty.A) []ty.A),
+	"FilterIndexed": new(func(func(int, ty.A) bool, []ty.A) []ty.A),
+	"Foldl":         new(func(func(ty.A, ty.B) ty.B, ty.B, []ty.A) ty.B),
+	"Foldr":         new(func(func(ty.A, ty.B) ty.B, ty.B, []ty.A) ty.B),
+	"Scanl":         new(func(func(ty.A, ty.B) ty.B, ty.B, []ty.A) []ty.B),
+	"Scanr":         new(func(func(ty.A, ty.B) ty.B, ty.B, []ty.A) []ty.B),
+	"Reduce":        new(func(func(ty.A, ty.A) ty.A, []ty.A) (ty.A, bool)),
+	"Concat":        new(func([][]ty.A) []ty.A),
+	"Reverse":       new(func([]ty.A) []ty.A),
+	"Copy":          new(func([]ty.A) []ty.A),
+	"ParMap":        new(func(func(ty.A) ty.B, []ty.A) []ty.B),
+	"ParMapN":       new(func(func(ty.A) ty.B, []ty.A, int) []ty.B),
+	"Each":          new(func(func(ty.A), []ty.A)),
+	"EachIndexed":   new(func(func(int, ty.A), []ty.A)),
+	"GroupBy":       new(func(func(ty.A) ty.B, []ty.A) map[ty.B][]ty.A),
+	"GroupByKey": new(
+		func(func(ty.A) ty.B, []ty.A) ([]ty.B, [][]ty.A)),
+	"Zip":       new(func([]ty.A, []ty.A) []ty.A),
+	"Partition": new(func(func(ty.A) bool, []ty.A) ([]ty.A, []ty.A)),
+	"Drop":      new(func(func(ty.A) bool, []ty.A) []ty.A),
+	"Take":      new(func(func(ty.A) bool, []ty.A) []ty.A),
+
+	// list_checkers.go
+	"All":    new(func(func(ty.A) bool, []ty.A) bool),
+	"Any":    new(func(func(ty.A) bool, []ty.A) bool),
+	"Count":  new(func(func(ty.A) bool, []ty.A) int),
+	"Detect": new(func(func(ty.A) bool, []ty.A) ty.A),
+	"None":   new(func(func(ty.A) bool, []ty.A) bool),
+	"One":    new(func(func(ty.A) bool, []ty.A) bool),
+
+	// list_replace.go
+	"Replace": new(func([]ty.A, []ty.A) []ty.A),
+
+	// list_window.go
+	"Chunk":      new(func([]ty.A, int) [][]ty.A),
+	"Window":     new(func([]ty.A, int, int) [][]ty.A),
+	"SplitAt":    new(func([]ty.A, int) ([]ty.A, []ty.A)),
+	"SplitWhen":  new(func(func(ty.A) bool, []ty.A) [][]ty.A),
+	"Interleave": new(func([][]ty.A) []ty.A),
+	"Transpose":  new(func([][]ty.A) [][]ty.A),
+	"Rotate":     new(func([]ty.A, int) []ty.A),
+	"TakeN":      new(func([]ty.A, int) []ty.A),
+	"DropN":      new(func([]ty.A, int) []ty.A),
+
+	// list_zip.go
+	"ZipWith": new(func(func(ty.A, ty.B) ty.C, []ty.A, []ty.B) []ty.C),
+	"ZipPairs": new(func([]ty.A, []ty.B) []struct {
+		First  ty.A
+		Second ty.B
+	}),
+	"Unzip": new(func([]struct {
+		First  ty.A
+		Second ty.B
+	}) ([]ty.A, []ty.B)),
+
+	// map.go
+	"Keys":   new(func(map[ty.A]ty.B) []ty.A),
+	"Values": new(func(map[ty.A]ty.B) []ty.B),
+	"MapMerge": new(func(map[ty.A]ty.B, map[ty.A]ty.B,
+		func(ty.A, ty.B, ty.B) ty.B) map[ty.A]ty.B),
+	"MapFilter": new(
+		func(func(ty.A, ty.B) bool, map[ty.A]ty.B) map[ty.A]ty.B),
+	"MapValues": new(
+		func(func(ty.B) ty.C, map[ty.A]ty.B) map[ty.A]ty.C),
+	"MapKeys": new(func(func(ty.A) ty.C, map[ty.A]ty.B) map[ty.C]ty.B),
+	"Invert":  new(func(map[ty.A]ty.B) map[ty.B]ty.A),
+	"Entries": new(func(map[ty.A]ty.B) []struct {
+		Key   ty.A
+		Value ty.B
+	}),
+	"FromEntries": new(func([]struct {
+		Key   ty.A
+		Value ty.B
+	}) map[ty.A]ty.B),
+	"SortedKeys": new(func(func(ty.A, ty.A) bool, map[ty.A]ty.B) []ty.A),
+
+	// min_max_sum.go
+	"MinInt":      new(func(func(ty.A) int64, []ty.A) int64),
+	"MaxInt":      new(func(func(ty.A) int64, []ty.A) int64),
+	"MinMaxInt":   new(func(func(ty.A) int64, []ty.A) (int64, int64)),
+	"MinFloat":    new(func(func(ty.A) float64, []ty.A) float64),
+	"MaxFloat":    new(func(func(ty.A) float64, []ty.A) float64),
+	"MinMaxFloat": new(func(func(ty.A) float64, []ty.A) (float64, float64)),
+	"SumInt":      new(func(func(ty.A) int64, []ty.A) int64),
+	"SumFloat":    new(func(func(ty.A) float64, []ty.A) float64),
+	"Min":         new(func(func(ty.A) ty.B, []ty.A) (ty.B, bool)),
+	"Max":         new(func(func(ty.A) ty.B, []ty.A) (ty.B, bool)),
+	"MinMax":      new(func(func(ty.A) ty.B, []ty.A) (ty.B, ty.B, bool)),
+	"Sum":         new(func(func(ty.A) ty.B, []ty.A) ty.B),
+	"MinBy":       new(func(func(ty.A) ty.B, []ty.A) (ty.A, bool)),
+	"MaxBy":       new(func(func(ty.A) ty.B, []ty.A) (ty.A, bool)),
+
+	// rand.go
+	"ShuffleGen":       new(func([]ty.A, *rand.Rand)),
+	"Shuffle":          new(func([]ty.A)),
+	"Sample":           new(func([]ty.A, int) []ty.A),
+	"SampleGen":        new(func([]ty.A, int, *rand.Rand) []ty.A),
+	"SampleReplace":    new(func([]ty.A, int) []ty.A),
+	"SampleReplaceGen": new(func([]ty.A, int, *rand.Rand) []ty.A),
+	"SampleWeighted":   new(func(func(ty.A) float64, []ty.A, int) []ty.A),
+	"SampleWeightedGen": new(
+		func(func(ty.A) float64, []ty.A, int, *rand.Rand) []ty.A),
+	"SampleChan":    new(func(<-chan ty.A, int) []ty.A),
+	"SampleChanGen": new(func(<-chan ty.A, int, *rand.Rand) []ty.A),
+
+	// set.go
+	"Set":          new(func([]ty.A) map[ty.A]bool),
+	"SetBy":        new(func([]ty.A) []ty.A),
+	"Union":        new(func(map[ty.A]bool, map[ty.A]bool) map[ty.A]bool),
+	"Intersection": new(func(map[ty.A]bool, map[ty.A]bool) map[ty.A]bool),
+	"Difference":   new(func(map[ty.A]bool, map[ty.A]bool) map[ty.A]bool),
+	"Uniq":         new(func([]ty.A) []ty.A),
+	"UniqBy":       new(func(func(ty.A) ty.B, []ty.A) []ty.A),
+	"Frequencies":  new(func([]ty.A) map[ty.A]int),
+	"CountBy":      new(func(func(ty.A) ty.B, []ty.A) map[ty.B]int),
+	"Duplicates":   new(func([]ty.A) []ty.A),
+	"Compact":      new(func([]ty.A) []ty.A),
+
+	// sort.go
+	"QuickSort":  new(func(func(ty.A, ty.A) bool, []ty.A) []ty.A),
+	"Sort":       new(func(func(ty.A, ty.A) bool, []ty.A)),
+	"SortStable": new(func(func(ty.A, ty.A) bool, []ty.A)),
+	"SortBy":     new(func(func(ty.A) ty.B, []ty.A) []ty.A),
+	"ThenBy": new(func(func(ty.A, ty.A) bool, func(ty.A, ty.A) bool) func(
+		ty.A, ty.A) bool),
+	"IsSorted":     new(func(func(ty.A, ty.A) bool, []ty.A) bool),
+	"LowerBound":   new(func(func(ty.A, ty.A) bool, []ty.A, ty.A) int),
+	"BinarySearch": new(func(func(ty.A, ty.A) bool, []ty.A, ty.A) (int, bool)),
+}
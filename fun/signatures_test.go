@@ -0,0 +1,156 @@
+package fun
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math/rand"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/ty"
+)
+
+// Type variables used in the documentation of this package.
+type (
+	K ty.TypeVariable
+	V ty.TypeVariable
+	J ty.TypeVariable
+	W ty.TypeVariable
+	N ty.TypeVariable
+)
+
+// TestSignatures checks that every function documented as having a
+// parametric type is registered with the type in its documentation, up to
+// the names of its type variables.
+func TestSignatures(t *testing.T) {
+	registry := ty.Registry{
+		"K": reflect.TypeOf(K{}),
+		"V": reflect.TypeOf(V{}),
+		"J": reflect.TypeOf(J{}),
+		"W": reflect.TypeOf(W{}),
+		"N": reflect.TypeOf(N{}),
+
+		"time.Duration": reflect.TypeOf(time.Duration(0)),
+		"rand.Rand":     reflect.TypeOf(rand.Rand{}),
+		"RetryPolicy":   reflect.TypeOf(RetryPolicy{}),
+	}
+	unregistered := map[string]bool{
+		"Pipe": true, "Partial": true, "ZipAll": true,
+	}
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	documented := make(map[string]bool)
+	for _, file := range pkgs["fun"].Files {
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Recv != nil || fd.Doc == nil {
+				continue
+			}
+			name := fd.Name.Name
+			doc := fd.Doc.Text()
+			if !strings.HasPrefix(doc, name+" has a parametric type") {
+				continue
+			}
+			documented[name] = true
+			if unregistered[name] {
+				continue
+			}
+
+			sig, ok := ty.LookupSignature("fun." + name)
+			if !ok {
+				t.Errorf("%s is not registered.", name)
+				continue
+			}
+			checkArity(t, sig, fd.Type)
+
+			documentedType, err := parseDocType(name, doc, registry)
+			if err != nil {
+				t.Errorf("%s: %s", name, err)
+				continue
+			}
+			bindings := make(map[reflect.Type]reflect.Type)
+			vars := ty.NewSignature(name, reflect.New(documentedType).
+				Interface()).TypeVars()
+			for i, tyvar := range sig.TypeVars() {
+				if i < len(vars) {
+					bindings[vars[i]] = tyvar
+				}
+			}
+			if len(vars) != len(bindings) ||
+				ty.Instantiate(documentedType, bindings) != sig.Type() {
+				t.Errorf("%s is documented as\n\t%s\nbut registered as\n\t%s",
+					name, ty.FormatType(documentedType), sig)
+			}
+		}
+	}
+	for name := range signatures {
+		if !documented[name] {
+			t.Errorf("%s is registered but not documented.", name)
+		}
+	}
+}
+
+// checkArity checks that `sig` has as many parameters and results as the
+// declared function type `ft`.
+func checkArity(t *testing.T, sig *ty.Signature, ft *ast.FuncType) {
+	count := func(fields *ast.FieldList) (n int) {
+		if fields == nil {
+			return 0
+		}
+		for _, field := range fields.List {
+			if len(field.Names) == 0 {
+				n++
+			}
+			n += len(field.Names)
+		}
+		return n
+	}
+	if len(sig.Params()) != count(ft.Params) ||
+		len(sig.Results()) != count(ft.Results) {
+		t.Errorf("%s does not match the arity of its declaration.", sig)
+	}
+}
+
+// parseDocType returns the parametric type in the documentation `doc` of
+// the function `name`, which is on the first indented line that starts
+// with `func` and may continue on the next lines.
+func parseDocType(
+	name, doc string,
+	registry ty.Registry,
+) (reflect.Type, error) {
+	var decl string
+	for _, line := range strings.Split(doc, "\n") {
+		line = strings.TrimSpace(line)
+		if decl == "" && !strings.HasPrefix(line, "func ") {
+			continue
+		}
+		decl += line
+		if !strings.HasSuffix(line, "(") {
+			break
+		}
+	}
+	expr := "func" + strings.TrimPrefix(decl, "func "+name)
+	return ty.ParseType(expr, registry)
+}
+
+func TestSignatureInTypeError(t *testing.T) {
+	defer func() {
+		want := "func fun.Map(func(A) B, []A) []B"
+		if err := recover(); !strings.Contains(fmt.Sprint(err), want) {
+			t.Fatalf("Expected the type error to contain %q, but got:\n%v",
+				want, err)
+		}
+	}()
+	Map(func(s string) int { return len(s) }, []int{1, 2, 3})
+}
@@ -1,6 +1,7 @@
 package fun
 
 import (
+	"math/bits"
 	"reflect"
 	"sort"
 
@@ -9,10 +10,14 @@ import (
 
 // QuickSort has a parametric type:
 //
-//	func QuickSort(less func(x1 A, x2 A) bool, []A) []A
+//	func QuickSort(less func(x1 A, x2 A) bool, xs []A) []A
 //
 // QuickSort applies the "quicksort" algorithm to return a new sorted list
-// of `xs`, where `xs` is not modified.
+// of `xs`, where `xs` is not modified. The sort is not stable.
+//
+// To avoid quadratic behavior on adversarial inputs, QuickSort uses the
+// median of three elements as its pivot and falls back to heapsort when
+// its recursion gets too deep. (This is also known as "introsort".)
 //
 // `less` should be a function that returns true if and only if `x1` is less
 // than `x2`.
@@ -22,50 +27,25 @@ func QuickSort(less, xs interface{}) interface{} {
 		less, xs)
 	vless, vxs, tys := chk.Args[0], chk.Args[1], chk.Returns[0]
 
-	var qsort func(left, right int)
-	var partition func(left, right, pivot int) int
 	xsind := Range(0, vxs.Len())
-
-	qsort = func(left, right int) {
-		if left >= right {
-			return
-		}
-		pivot := (left + right) / 2
-		pivot = partition(left, right, pivot)
-
-		qsort(left, pivot-1)
-		qsort(pivot+1, right)
-	}
-	partition = func(left, right, pivot int) int {
-		vpivot := xsind[pivot]
-		xsind[pivot], xsind[right] = xsind[right], xsind[pivot]
-
-		ind := left
-		for i := left; i < right; i++ {
-			if call1(vless, vxs.Index(xsind[i]), vxs.Index(vpivot)).Bool() {
-				xsind[i], xsind[ind] = xsind[ind], xsind[i]
-				ind++
-			}
-		}
-		xsind[ind], xsind[right] = xsind[right], xsind[ind]
-		return ind
-	}
+	is := &indexSorter{vless, vxs, xsind}
 
 	// Sort `xsind` in place.
-	qsort(0, len(xsind)-1)
+	introsort(is, 0, len(xsind)-1, 2*bits.Len(uint(len(xsind))))
 
 	vys := reflect.MakeSlice(tys, len(xsind), len(xsind))
 	for i, xsIndex := range xsind {
 		vys.Index(i).Set(vxs.Index(xsIndex))
 	}
-	return vys.Interface()
+	return verified(chk, vys.Interface())
 }
 
 // Sort has a parametric type:
 //
-//	func Sort(less func(x1 A, x2 A) bool, []A)
+//	func Sort(less func(x1 A, x2 A) bool, xs []A)
 //
 // Sort uses the standard library `sort` package to sort `xs` in place.
+// The sort is not stable. (See `SortStable`.)
 //
 // `less` should be a function that returns true if and only if `x1` is less
 // than `x2`.
@@ -78,6 +58,259 @@ func Sort(less, xs interface{}) {
 	sort.Sort(&sortable{vless, vxs, swapperOf(vxs.Type().Elem())})
 }
 
+// SortStable has a parametric type:
+//
+//	func SortStable(less func(x1 A, x2 A) bool, xs []A)
+//
+// SortStable is just like `Sort`, except the sort is stable. That is, equal
+// elements keep their original order.
+func SortStable(less, xs interface{}) {
+	chk := ty.Check(
+		new(func(func(ty.A, ty.A) bool, []ty.A)),
+		less, xs)
+
+	vless, vxs := chk.Args[0], chk.Args[1]
+	sort.Stable(&sortable{vless, vxs, swapperOf(vxs.Type().Elem())})
+}
+
+// SortBy has a parametric type:
+//
+//	func SortBy(key func(A) K, xs []A) []A
+//
+// SortBy returns a new list of the elements of `xs` sorted in ascending order
+// of the keys returned by `key`, where `xs` is not modified. Keys may have any
+// type and are ordered with `Compare`. The sort is stable.
+//
+// `key` is called exactly once for each element of `xs`.
+func SortBy(key, xs interface{}) interface{} {
+	chk := ty.Check(
+		new(func(func(ty.A) ty.B, []ty.A) []ty.A),
+		key, xs)
+	vkey, vxs, tys := chk.Args[0], chk.Args[1], chk.Returns[0]
+
+	// Decorate each element with its key, sort and then undecorate.
+	xsLen := vxs.Len()
+	keys := make([]reflect.Value, xsLen)
+	for i := 0; i < xsLen; i++ {
+		keys[i] = call1(vkey, vxs.Index(i))
+	}
+	xsind := Range(0, xsLen)
+	sort.SliceStable(xsind, func(i, j int) bool {
+		return deepCompare(keys[xsind[i]], keys[xsind[j]]) < 0
+	})
+
+	vys := reflect.MakeSlice(tys, xsLen, xsLen)
+	for i, xsIndex := range xsind {
+		vys.Index(i).Set(vxs.Index(xsIndex))
+	}
+	return verified(chk, vys.Interface())
+}
+
+// ThenBy has a parametric type:
+//
+//	func ThenBy(first func(A, A) bool, second func(A, A) bool) func(A, A) bool
+//
+// ThenBy returns a `less` function that orders elements with `first`, and
+// orders elements that are equal according to `first` with `second`.
+// Calls to ThenBy may be nested to order elements by any number of keys:
+//
+//	byYear := func(a, b Album) bool { return a.Year < b.Year }
+//	byTitle := func(a, b Album) bool { return a.Title < b.Title }
+//	SortStable(ThenBy(byYear, byTitle), albums)
+func ThenBy(first, second interface{}) interface{} {
+	chk := ty.Check(
+		new(func(func(ty.A, ty.A) bool, func(ty.A, ty.A) bool) func(
+			ty.A, ty.A) bool),
+		first, second)
+	vfirst, vsecond := chk.Args[0], chk.Args[1]
+
+	less := func(in []reflect.Value) []reflect.Value {
+		if call1(vfirst, in[0], in[1]).Bool() {
+			return []reflect.Value{reflect.ValueOf(true)}
+		}
+		if call1(vfirst, in[1], in[0]).Bool() {
+			return []reflect.Value{reflect.ValueOf(false)}
+		}
+		return []reflect.Value{call1(vsecond, in[0], in[1])}
+	}
+	return verified(chk, reflect.MakeFunc(vfirst.Type(), less).Interface())
+}
+
+// IsSorted has a parametric type:
+//
+//	func IsSorted(less func(x1 A, x2 A) bool, xs []A) bool
+//
+// IsSorted returns true if `xs` is sorted according to `less`.
+func IsSorted(less, xs interface{}) bool {
+	chk := ty.Check(
+		new(func(func(ty.A, ty.A) bool, []ty.A) bool),
+		less, xs)
+
+	vless, vxs := chk.Args[0], chk.Args[1]
+	return sort.IsSorted(&sortable{vless, vxs, swapperOf(vxs.Type().Elem())})
+}
+
+// LowerBound has a parametric type:
+//
+//	func LowerBound(less func(x1 A, x2 A) bool, xs []A, x A) int
+//
+// LowerBound returns the index of the first element of `xs` that is not less
+// than `x`, or the length of `xs` if there is no such element. `xs` must be
+// sorted according to `less`.
+//
+// LowerBound is the position at which `x` would be inserted to keep `xs`
+// sorted.
+func LowerBound(less, xs, x interface{}) int {
+	chk := ty.Check(
+		new(func(func(ty.A, ty.A) bool, []ty.A, ty.A) int),
+		less, xs, x)
+	vless, vxs, vx := chk.Args[0], chk.Args[1], chk.Args[2]
+
+	return lowerBound(vless, vxs, vx)
+}
+
+// BinarySearch has a parametric type:
+//
+//	func BinarySearch(less func(x1 A, x2 A) bool, xs []A, x A) (int, bool)
+//
+// BinarySearch searches for `x` in `xs`, which must be sorted according to
+// `less`. It returns the index of the first element equal to `x` (that is,
+// neither less than nor greater than `x`) and true, or the index at which
+// `x` would be inserted and false if there is no such element.
+func BinarySearch(less, xs, x interface{}) (int, bool) {
+	chk := ty.Check(
+		new(func(func(ty.A, ty.A) bool, []ty.A, ty.A) (int, bool)),
+		less, xs, x)
+	vless, vxs, vx := chk.Args[0], chk.Args[1], chk.Args[2]
+
+	i := lowerBound(vless, vxs, vx)
+	found := i < vxs.Len() && !call1(vless, vx, vxs.Index(i)).Bool()
+	return i, found
+}
+
+func lowerBound(vless, vxs, vx reflect.Value) int {
+	return sort.Search(vxs.Len(), func(i int) bool {
+		return !call1(vless, vxs.Index(i), vx).Bool()
+	})
+}
+
+// indexSorter sorts a list of indices into `xs` rather than `xs` itself.
+type indexSorter struct {
+	less  reflect.Value
+	xs    reflect.Value
+	xsind []int
+}
+
+func (is *indexSorter) Less(i, j int) bool {
+	ith, jth := is.xs.Index(is.xsind[i]), is.xs.Index(is.xsind[j])
+	return call1(is.less, ith, jth).Bool()
+}
+
+func (is *indexSorter) Swap(i, j int) {
+	is.xsind[i], is.xsind[j] = is.xsind[j], is.xsind[i]
+}
+
+// lessSwapper is the part of `sort.Interface` needed by `introsort`.
+type lessSwapper interface {
+	Less(i, j int) bool
+	Swap(i, j int)
+}
+
+// introsort sorts the inclusive range [left, right] of `data` with quicksort,
+// until `depth` levels of recursion are exhausted, at which point it switches
+// to heapsort. Small ranges are sorted with insertion sort.
+func introsort(data lessSwapper, left, right, depth int) {
+	for right-left >= 12 {
+		if depth == 0 {
+			heapsort(data, left, right)
+			return
+		}
+		depth--
+
+		pivot := partition(data, left, right, medianOfThree(data, left, right))
+
+		// Recurse into the smaller side and loop on the larger side, so that
+		// the stack never grows beyond O(log n).
+		if pivot-left < right-pivot {
+			introsort(data, left, pivot-1, depth)
+			left = pivot + 1
+		} else {
+			introsort(data, pivot+1, right, depth)
+			right = pivot - 1
+		}
+	}
+	insertionSort(data, left, right)
+}
+
+// partition moves every element less than the element at `pivot` before it
+// and returns the pivot's new position.
+func partition(data lessSwapper, left, right, pivot int) int {
+	data.Swap(pivot, right)
+	ind := left
+	for i := left; i < right; i++ {
+		if data.Less(i, right) {
+			data.Swap(i, ind)
+			ind++
+		}
+	}
+	data.Swap(ind, right)
+	return ind
+}
+
+// medianOfThree returns whichever of the first, middle and last elements of
+// the range [left, right] is between the other two.
+func medianOfThree(data lessSwapper, left, right int) int {
+	mid := left + (right-left)/2
+	if data.Less(mid, left) {
+		left, mid = mid, left
+	}
+	if data.Less(right, mid) {
+		mid = right
+		if data.Less(mid, left) {
+			mid = left
+		}
+	}
+	return mid
+}
+
+func insertionSort(data lessSwapper, left, right int) {
+	for i := left + 1; i <= right; i++ {
+		for j := i; j > left && data.Less(j, j-1); j-- {
+			data.Swap(j, j-1)
+		}
+	}
+}
+
+func heapsort(data lessSwapper, left, right int) {
+	n := right - left + 1
+	for i := n/2 - 1; i >= 0; i-- {
+		siftDown(data, left, i, n)
+	}
+	for i := n - 1; i > 0; i-- {
+		data.Swap(left, left+i)
+		siftDown(data, left, 0, i)
+	}
+}
+
+// siftDown restores the max-heap property of the heap of size `n` that
+// starts at `offset`, beginning at the heap index `root`.
+func siftDown(data lessSwapper, offset, root, n int) {
+	for {
+		child := 2*root + 1
+		if child >= n {
+			return
+		}
+		if child+1 < n && data.Less(offset+child, offset+child+1) {
+			child++
+		}
+		if !data.Less(offset+root, offset+child) {
+			return
+		}
+		data.Swap(offset+root, offset+child)
+		root = child
+	}
+}
+
 type sortable struct {
 	less    reflect.Value
 	xs      reflect.Value
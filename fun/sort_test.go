@@ -22,6 +22,125 @@ func TestQuickSort(t *testing.T) {
 	assertDeep(t, sorted, []int{15, 10, 6, 5, 3, 1})
 }
 
+func TestQuickSortLarge(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	inputs := [][]int{
+		randIntSlice(1000, 0),
+		randIntSlice(1000, 10),
+		Range(0, 1000),
+		Reverse(Range(0, 1000)).([]int),
+		make([]int, 1000),
+	}
+	for _, xs := range inputs {
+		expected := Copy(xs).([]int)
+		sort.Ints(expected)
+		assertDeep(t, QuickSort(less, xs), expected)
+	}
+}
+
+func TestQuickSortAdversarial(t *testing.T) {
+	// Count comparisons to make sure that QuickSort falls back to heapsort
+	// rather than taking quadratic time on an "organ pipe" input, which is
+	// a bad case for median of three pivots.
+	comparisons := 0
+	less := func(a, b int) bool {
+		comparisons++
+		return a < b
+	}
+	n := 10000
+	xs := make([]int, n)
+	for i := range xs {
+		if i < n/2 {
+			xs[i] = i
+		} else {
+			xs[i] = n - i
+		}
+	}
+	assertDeep(t, IsSorted(less, QuickSort(less, xs)), true)
+	if comparisons > 50*n*14 {
+		t.Fatalf("QuickSort made %d comparisons to sort %d elements",
+			comparisons, n)
+	}
+}
+
+func TestSortStable(t *testing.T) {
+	type album struct {
+		title string
+		year  int
+	}
+	albums := []album{
+		{"Darkness", 1978}, {"Greetings", 1973},
+		{"Born to Run", 1975}, {"WIESS", 1973},
+	}
+	byYear := func(a, b album) bool { return a.year < b.year }
+	byTitle := func(a, b album) bool { return a.title < b.title }
+
+	stable := Copy(albums).([]album)
+	SortStable(byYear, stable)
+	assertDeep(t, stable, []album{
+		{"Greetings", 1973}, {"WIESS", 1973},
+		{"Born to Run", 1975}, {"Darkness", 1978},
+	})
+
+	reversed := Reverse(albums).([]album)
+	SortStable(ThenBy(byYear, byTitle), reversed)
+	assertDeep(t, reversed, stable)
+
+	byYearDesc := func(a, b album) bool { return a.year > b.year }
+	SortStable(ThenBy(byYearDesc, byTitle), reversed)
+	assertDeep(t, reversed, []album{
+		{"Darkness", 1978}, {"Born to Run", 1975},
+		{"Greetings", 1973}, {"WIESS", 1973},
+	})
+}
+
+func TestSortBy(t *testing.T) {
+	calls := 0
+	length := func(s string) int {
+		calls++
+		return len(s)
+	}
+	words := []string{"ccc", "a", "bb", "d", "", "ee"}
+	sorted := SortBy(length, words).([]string)
+
+	assertDeep(t, sorted, []string{"", "a", "d", "bb", "ee", "ccc"})
+	assertDeep(t, words[0], "ccc")
+	assertDeep(t, calls, len(words))
+
+	// Keys don't need to be comparable with `<`.
+	digits := func(n int) []int { return []int{n % 10, n / 10} }
+	assertDeep(t, SortBy(digits, []int{21, 12, 11, 22}),
+		[]int{11, 21, 12, 22})
+}
+
+func TestIsSorted(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	assertDeep(t, IsSorted(less, []int{1, 2, 2, 3}), true)
+	assertDeep(t, IsSorted(less, []int{1, 3, 2}), false)
+	assertDeep(t, IsSorted(less, []int{}), true)
+}
+
+func TestBinarySearch(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	xs := []int{1, 3, 3, 3, 7}
+
+	assertDeep(t, LowerBound(less, xs, 3), 1)
+	assertDeep(t, LowerBound(less, xs, 0), 0)
+	assertDeep(t, LowerBound(less, xs, 8), 5)
+
+	i, found := BinarySearch(less, xs, 3)
+	assertDeep(t, i, 1)
+	assertDeep(t, found, true)
+
+	i, found = BinarySearch(less, xs, 5)
+	assertDeep(t, i, 4)
+	assertDeep(t, found, false)
+
+	i, found = BinarySearch(less, []int{}, 5)
+	assertDeep(t, i, 0)
+	assertDeep(t, found, false)
+}
+
 func BenchmarkSort(b *testing.B) {
 	if flagBuiltin {
 		benchmarkSortBuiltin(b)
@@ -0,0 +1,1050 @@
+package fun
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/BurntSushi/ty"
+)
+
+// The functions in this file return the parametric functions of this
+// package specialized to a concrete function type with `ty.Specialize`. The
+// concrete type is given as a pointer to a nil function, and is checked
+// against the parametric type once. The result can be type asserted to the
+// concrete type and called like any other function, e.g.,
+//
+//	mapItoa := MapOf(
+//		new(func(func(int) string, []int) []string),
+//	).(func(func(int) string, []int) []string)
+//
+// The specialized function calls the parametric function, whose check of
+// the concrete type is remembered by `ty.Specialize`, so the arguments are
+// not unified again on each call.
+//
+// Every function whose parametric type is registered with
+// `ty.RegisterSignature` (see signatures.go) has one, with the same type,
+// except for `Unzip` and `FromEntries`: their type variables only occur in
+// structs, which `ty.Check` does not unify. `Pipe`, `Partial` and `ZipAll`
+// do not have one either, since their parametric types depend on the number
+// of arguments they are given.
+
+// AsyncChanOf returns `AsyncChan` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(*chan A) (chan<- A, <-chan A)
+func AsyncChanOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(*chan ty.A) (chan<- ty.A, <-chan ty.A)),
+		AsyncChan, target)
+}
+
+// CycleEachOf returns `CycleEach` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(func(A), []A, int)
+func CycleEachOf(target interface{}) interface{} {
+	return ty.Specialize(new(func(func(ty.A), []ty.A, int)), CycleEach, target)
+}
+
+// CycleMapOf returns `CycleMap` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(func(A) B, []A, int) []B
+func CycleMapOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) ty.B, []ty.A, int) []ty.B),
+		CycleMap, target)
+}
+
+// EqualOf returns `Equal` specialized to `target`, which must be a pointer to a
+// nil function whose type is an instance of
+//
+//	func(A, A) bool
+func EqualOf(target interface{}) interface{} {
+	return ty.Specialize(new(func(ty.A, ty.A) bool), Equal, target)
+}
+
+// CompareOf returns `Compare` specialized to `target`, which must be a pointer
+// to a nil function whose type is an instance of
+//
+//	func(A, A) int
+func CompareOf(target interface{}) interface{} {
+	return ty.Specialize(new(func(ty.A, ty.A) int), Compare, target)
+}
+
+// DeepHashOf returns `DeepHash` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(A) uint64
+func DeepHashOf(target interface{}) interface{} {
+	return ty.Specialize(new(func(ty.A) uint64), DeepHash, target)
+}
+
+// MemoOf returns `Memo` specialized to `target`, which must be a pointer to a
+// nil function whose type is an instance of
+//
+//	func(func(A) B) func(A) B
+func MemoOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) ty.B) func(ty.A) ty.B),
+		Memo, target)
+}
+
+// MemoByOf returns `MemoBy` specialized to `target`, which must be a pointer to
+// a nil function whose type is an instance of
+//
+//	func(func(A) B) func(A) B
+func MemoByOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) ty.B) func(ty.A) ty.B),
+		MemoBy, target)
+}
+
+// ComposeOf returns `Compose` specialized to `target`, which must be a pointer
+// to a nil function whose type is an instance of
+//
+//	func(func(B) C, func(A) B) func(A) C
+func ComposeOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.B) ty.C, func(ty.A) ty.B) func(ty.A) ty.C),
+		Compose, target)
+}
+
+// Curry2Of returns `Curry2` specialized to `target`, which must be a pointer to
+// a nil function whose type is an instance of
+//
+//	func(func(A, B) C) func(A) func(B) C
+func Curry2Of(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A, ty.B) ty.C) func(ty.A) func(ty.B) ty.C),
+		Curry2, target)
+}
+
+// Uncurry2Of returns `Uncurry2` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(func(A) func(B) C) func(A, B) C
+func Uncurry2Of(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) func(ty.B) ty.C) func(ty.A, ty.B) ty.C),
+		Uncurry2, target)
+}
+
+// FlipOf returns `Flip` specialized to `target`, which must be a pointer to a
+// nil function whose type is an instance of
+//
+//	func(func(A, B) C) func(B, A) C
+func FlipOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A, ty.B) ty.C) func(ty.B, ty.A) ty.C),
+		Flip, target)
+}
+
+// OnceOf returns `Once` specialized to `target`, which must be a pointer to a
+// nil function whose type is an instance of
+//
+//	func(F) F
+func OnceOf(target interface{}) interface{} {
+	return ty.Specialize(new(func(ty.F) ty.F), Once, target)
+}
+
+// DebounceOf returns `Debounce` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(F, time.Duration) F
+func DebounceOf(target interface{}) interface{} {
+	return ty.Specialize(new(func(ty.F, time.Duration) ty.F), Debounce, target)
+}
+
+// ThrottleOf returns `Throttle` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(F, time.Duration) F
+func ThrottleOf(target interface{}) interface{} {
+	return ty.Specialize(new(func(ty.F, time.Duration) ty.F), Throttle, target)
+}
+
+// RetryOf returns `Retry` specialized to `target`, which must be a pointer to a
+// nil function whose type is an instance of
+//
+//	func(F, RetryPolicy) F
+func RetryOf(target interface{}) interface{} {
+	return ty.Specialize(new(func(ty.F, RetryPolicy) ty.F), Retry, target)
+}
+
+// MapOf returns `Map` specialized to `target`, which must be a pointer to a nil
+// function whose type is an instance of
+//
+//	func(func(A) B, []A) []B
+func MapOf(target interface{}) interface{} {
+	return ty.Specialize(new(func(func(ty.A) ty.B, []ty.A) []ty.B), Map, target)
+}
+
+// MapIndexedOf returns `MapIndexed` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(func(int, A) B, []A) []B
+func MapIndexedOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(int, ty.A) ty.B, []ty.A) []ty.B),
+		MapIndexed, target)
+}
+
+// FlatMapOf returns `FlatMap` specialized to `target`, which must be a pointer
+// to a nil function whose type is an instance of
+//
+//	func(func(A) []B, []A) []B
+func FlatMapOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) []ty.B, []ty.A) []ty.B),
+		FlatMap, target)
+}
+
+// UnfoldOf returns `Unfold` specialized to `target`, which must be a pointer to
+// a nil function whose type is an instance of
+//
+//	func(func(B) (A, B, bool), B) []A
+func UnfoldOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.B) (ty.A, ty.B, bool), ty.B) []ty.A),
+		Unfold, target)
+}
+
+// FilterOf returns `Filter` specialized to `target`, which must be a pointer to
+// a nil function whose type is an instance of
+//
+//	func(func(A) bool, []A) []A
+func FilterOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) bool, []ty.A) []ty.A),
+		Filter, target)
+}
+
+// FilterIndexedOf returns `FilterIndexed` specialized to `target`, which must
+// be a pointer to a nil function whose type is an instance of
+//
+//	func(func(int, A) bool, []A) []A
+func FilterIndexedOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(int, ty.A) bool, []ty.A) []ty.A),
+		FilterIndexed, target)
+}
+
+// FoldlOf returns `Foldl` specialized to `target`, which must be a pointer to a
+// nil function whose type is an instance of
+//
+//	func(func(A, B) B, B, []A) B
+func FoldlOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A, ty.B) ty.B, ty.B, []ty.A) ty.B),
+		Foldl, target)
+}
+
+// FoldrOf returns `Foldr` specialized to `target`, which must be a pointer to a
+// nil function whose type is an instance of
+//
+//	func(func(A, B) B, B, []A) B
+func FoldrOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A, ty.B) ty.B, ty.B, []ty.A) ty.B),
+		Foldr, target)
+}
+
+// ScanlOf returns `Scanl` specialized to `target`, which must be a pointer to a
+// nil function whose type is an instance of
+//
+//	func(func(A, B) B, B, []A) []B
+func ScanlOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A, ty.B) ty.B, ty.B, []ty.A) []ty.B),
+		Scanl, target)
+}
+
+// ScanrOf returns `Scanr` specialized to `target`, which must be a pointer to a
+// nil function whose type is an instance of
+//
+//	func(func(A, B) B, B, []A) []B
+func ScanrOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A, ty.B) ty.B, ty.B, []ty.A) []ty.B),
+		Scanr, target)
+}
+
+// ReduceOf returns `Reduce` specialized to `target`, which must be a pointer to
+// a nil function whose type is an instance of
+//
+//	func(func(A, A) A, []A) (A, bool)
+func ReduceOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A, ty.A) ty.A, []ty.A) (ty.A, bool)),
+		Reduce, target)
+}
+
+// ConcatOf returns `Concat` specialized to `target`, which must be a pointer to
+// a nil function whose type is an instance of
+//
+//	func([][]A) []A
+func ConcatOf(target interface{}) interface{} {
+	return ty.Specialize(new(func([][]ty.A) []ty.A), Concat, target)
+}
+
+// ReverseOf returns `Reverse` specialized to `target`, which must be a pointer
+// to a nil function whose type is an instance of
+//
+//	func([]A) []A
+func ReverseOf(target interface{}) interface{} {
+	return ty.Specialize(new(func([]ty.A) []ty.A), Reverse, target)
+}
+
+// CopyOf returns `Copy` specialized to `target`, which must be a pointer to a
+// nil function whose type is an instance of
+//
+//	func([]A) []A
+func CopyOf(target interface{}) interface{} {
+	return ty.Specialize(new(func([]ty.A) []ty.A), Copy, target)
+}
+
+// ParMapOf returns `ParMap` specialized to `target`, which must be a pointer to
+// a nil function whose type is an instance of
+//
+//	func(func(A) B, []A) []B
+func ParMapOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) ty.B, []ty.A) []ty.B),
+		ParMap, target)
+}
+
+// ParMapNOf returns `ParMapN` specialized to `target`, which must be a pointer
+// to a nil function whose type is an instance of
+//
+//	func(func(A) B, []A, int) []B
+func ParMapNOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) ty.B, []ty.A, int) []ty.B),
+		ParMapN, target)
+}
+
+// EachOf returns `Each` specialized to `target`, which must be a pointer to a
+// nil function whose type is an instance of
+//
+//	func(func(A), []A)
+func EachOf(target interface{}) interface{} {
+	return ty.Specialize(new(func(func(ty.A), []ty.A)), Each, target)
+}
+
+// EachIndexedOf returns `EachIndexed` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(func(int, A), []A)
+func EachIndexedOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(int, ty.A), []ty.A)),
+		EachIndexed, target)
+}
+
+// GroupByOf returns `GroupBy` specialized to `target`, which must be a pointer
+// to a nil function whose type is an instance of
+//
+//	func(func(A) B, []A) map[B][]A
+func GroupByOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) ty.B, []ty.A) map[ty.B][]ty.A),
+		GroupBy, target)
+}
+
+// GroupByKeyOf returns `GroupByKey` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(func(A) B, []A) ([]B, [][]A)
+func GroupByKeyOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) ty.B, []ty.A) ([]ty.B, [][]ty.A)),
+		GroupByKey, target)
+}
+
+// ZipOf returns `Zip` specialized to `target`, which must be a pointer to a nil
+// function whose type is an instance of
+//
+//	func([]A, []A) []A
+func ZipOf(target interface{}) interface{} {
+	return ty.Specialize(new(func([]ty.A, []ty.A) []ty.A), Zip, target)
+}
+
+// PartitionOf returns `Partition` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(func(A) bool, []A) ([]A, []A)
+func PartitionOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) bool, []ty.A) ([]ty.A, []ty.A)),
+		Partition, target)
+}
+
+// DropOf returns `Drop` specialized to `target`, which must be a pointer to a
+// nil function whose type is an instance of
+//
+//	func(func(A) bool, []A) []A
+func DropOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) bool, []ty.A) []ty.A),
+		Drop, target)
+}
+
+// TakeOf returns `Take` specialized to `target`, which must be a pointer to a
+// nil function whose type is an instance of
+//
+//	func(func(A) bool, []A) []A
+func TakeOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) bool, []ty.A) []ty.A),
+		Take, target)
+}
+
+// AllOf returns `All` specialized to `target`, which must be a pointer to a nil
+// function whose type is an instance of
+//
+//	func(func(A) bool, []A) bool
+func AllOf(target interface{}) interface{} {
+	return ty.Specialize(new(func(func(ty.A) bool, []ty.A) bool), All, target)
+}
+
+// AnyOf returns `Any` specialized to `target`, which must be a pointer to a nil
+// function whose type is an instance of
+//
+//	func(func(A) bool, []A) bool
+func AnyOf(target interface{}) interface{} {
+	return ty.Specialize(new(func(func(ty.A) bool, []ty.A) bool), Any, target)
+}
+
+// CountOf returns `Count` specialized to `target`, which must be a pointer to a
+// nil function whose type is an instance of
+//
+//	func(func(A) bool, []A) int
+func CountOf(target interface{}) interface{} {
+	return ty.Specialize(new(func(func(ty.A) bool, []ty.A) int), Count, target)
+}
+
+// DetectOf returns `Detect` specialized to `target`, which must be a pointer to
+// a nil function whose type is an instance of
+//
+//	func(func(A) bool, []A) A
+func DetectOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) bool, []ty.A) ty.A),
+		Detect, target)
+}
+
+// NoneOf returns `None` specialized to `target`, which must be a pointer to a
+// nil function whose type is an instance of
+//
+//	func(func(A) bool, []A) bool
+func NoneOf(target interface{}) interface{} {
+	return ty.Specialize(new(func(func(ty.A) bool, []ty.A) bool), None, target)
+}
+
+// OneOf returns `One` specialized to `target`, which must be a pointer to a nil
+// function whose type is an instance of
+//
+//	func(func(A) bool, []A) bool
+func OneOf(target interface{}) interface{} {
+	return ty.Specialize(new(func(func(ty.A) bool, []ty.A) bool), One, target)
+}
+
+// ReplaceOf returns `Replace` specialized to `target`, which must be a pointer
+// to a nil function whose type is an instance of
+//
+//	func([]A, []A) []A
+func ReplaceOf(target interface{}) interface{} {
+	return ty.Specialize(new(func([]ty.A, []ty.A) []ty.A), Replace, target)
+}
+
+// ChunkOf returns `Chunk` specialized to `target`, which must be a pointer to a
+// nil function whose type is an instance of
+//
+//	func([]A, int) [][]A
+func ChunkOf(target interface{}) interface{} {
+	return ty.Specialize(new(func([]ty.A, int) [][]ty.A), Chunk, target)
+}
+
+// WindowOf returns `Window` specialized to `target`, which must be a pointer to
+// a nil function whose type is an instance of
+//
+//	func([]A, int, int) [][]A
+func WindowOf(target interface{}) interface{} {
+	return ty.Specialize(new(func([]ty.A, int, int) [][]ty.A), Window, target)
+}
+
+// SplitAtOf returns `SplitAt` specialized to `target`, which must be a pointer
+// to a nil function whose type is an instance of
+//
+//	func([]A, int) ([]A, []A)
+func SplitAtOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func([]ty.A, int) ([]ty.A, []ty.A)),
+		SplitAt, target)
+}
+
+// SplitWhenOf returns `SplitWhen` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(func(A) bool, []A) [][]A
+func SplitWhenOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) bool, []ty.A) [][]ty.A),
+		SplitWhen, target)
+}
+
+// InterleaveOf returns `Interleave` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func([][]A) []A
+func InterleaveOf(target interface{}) interface{} {
+	return ty.Specialize(new(func([][]ty.A) []ty.A), Interleave, target)
+}
+
+// TransposeOf returns `Transpose` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func([][]A) [][]A
+func TransposeOf(target interface{}) interface{} {
+	return ty.Specialize(new(func([][]ty.A) [][]ty.A), Transpose, target)
+}
+
+// RotateOf returns `Rotate` specialized to `target`, which must be a pointer to
+// a nil function whose type is an instance of
+//
+//	func([]A, int) []A
+func RotateOf(target interface{}) interface{} {
+	return ty.Specialize(new(func([]ty.A, int) []ty.A), Rotate, target)
+}
+
+// TakeNOf returns `TakeN` specialized to `target`, which must be a pointer to a
+// nil function whose type is an instance of
+//
+//	func([]A, int) []A
+func TakeNOf(target interface{}) interface{} {
+	return ty.Specialize(new(func([]ty.A, int) []ty.A), TakeN, target)
+}
+
+// DropNOf returns `DropN` specialized to `target`, which must be a pointer to a
+// nil function whose type is an instance of
+//
+//	func([]A, int) []A
+func DropNOf(target interface{}) interface{} {
+	return ty.Specialize(new(func([]ty.A, int) []ty.A), DropN, target)
+}
+
+// ZipWithOf returns `ZipWith` specialized to `target`, which must be a pointer
+// to a nil function whose type is an instance of
+//
+//	func(func(A, B) C, []A, []B) []C
+func ZipWithOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A, ty.B) ty.C, []ty.A, []ty.B) []ty.C),
+		ZipWith, target)
+}
+
+// ZipPairsOf returns `ZipPairs` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func([]A, []B) []struct{ First A; Second B }
+func ZipPairsOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func([]ty.A, []ty.B) []struct {
+			First  ty.A
+			Second ty.B
+		}),
+		ZipPairs, target)
+}
+
+// KeysOf returns `Keys` specialized to `target`, which must be a pointer to a
+// nil function whose type is an instance of
+//
+//	func(map[A]B) []A
+func KeysOf(target interface{}) interface{} {
+	return ty.Specialize(new(func(map[ty.A]ty.B) []ty.A), Keys, target)
+}
+
+// ValuesOf returns `Values` specialized to `target`, which must be a pointer to
+// a nil function whose type is an instance of
+//
+//	func(map[A]B) []B
+func ValuesOf(target interface{}) interface{} {
+	return ty.Specialize(new(func(map[ty.A]ty.B) []ty.B), Values, target)
+}
+
+// MapMergeOf returns `MapMerge` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(map[A]B, map[A]B, func(A, B, B) B) map[A]B
+func MapMergeOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(map[ty.A]ty.B, map[ty.A]ty.B,
+			func(ty.A, ty.B, ty.B) ty.B) map[ty.A]ty.B),
+		MapMerge, target)
+}
+
+// MapFilterOf returns `MapFilter` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(func(A, B) bool, map[A]B) map[A]B
+func MapFilterOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A, ty.B) bool, map[ty.A]ty.B) map[ty.A]ty.B),
+		MapFilter, target)
+}
+
+// MapValuesOf returns `MapValues` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(func(B) C, map[A]B) map[A]C
+func MapValuesOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.B) ty.C, map[ty.A]ty.B) map[ty.A]ty.C),
+		MapValues, target)
+}
+
+// MapKeysOf returns `MapKeys` specialized to `target`, which must be a pointer
+// to a nil function whose type is an instance of
+//
+//	func(func(A) C, map[A]B) map[C]B
+func MapKeysOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) ty.C, map[ty.A]ty.B) map[ty.C]ty.B),
+		MapKeys, target)
+}
+
+// InvertOf returns `Invert` specialized to `target`, which must be a pointer to
+// a nil function whose type is an instance of
+//
+//	func(map[A]B) map[B]A
+func InvertOf(target interface{}) interface{} {
+	return ty.Specialize(new(func(map[ty.A]ty.B) map[ty.B]ty.A), Invert, target)
+}
+
+// EntriesOf returns `Entries` specialized to `target`, which must be a pointer
+// to a nil function whose type is an instance of
+//
+//	func(map[A]B) []struct{ Key A; Value B }
+func EntriesOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(map[ty.A]ty.B) []struct {
+			Key   ty.A
+			Value ty.B
+		}),
+		Entries, target)
+}
+
+// SortedKeysOf returns `SortedKeys` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(func(A, A) bool, map[A]B) []A
+func SortedKeysOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A, ty.A) bool, map[ty.A]ty.B) []ty.A),
+		SortedKeys, target)
+}
+
+// MinIntOf returns `MinInt` specialized to `target`, which must be a pointer to
+// a nil function whose type is an instance of
+//
+//	func(func(A) int64, []A) int64
+func MinIntOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) int64, []ty.A) int64),
+		MinInt, target)
+}
+
+// MaxIntOf returns `MaxInt` specialized to `target`, which must be a pointer to
+// a nil function whose type is an instance of
+//
+//	func(func(A) int64, []A) int64
+func MaxIntOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) int64, []ty.A) int64),
+		MaxInt, target)
+}
+
+// MinMaxIntOf returns `MinMaxInt` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(func(A) int64, []A) (int64, int64)
+func MinMaxIntOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) int64, []ty.A) (int64, int64)),
+		MinMaxInt, target)
+}
+
+// MinFloatOf returns `MinFloat` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(func(A) float64, []A) float64
+func MinFloatOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) float64, []ty.A) float64),
+		MinFloat, target)
+}
+
+// MaxFloatOf returns `MaxFloat` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(func(A) float64, []A) float64
+func MaxFloatOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) float64, []ty.A) float64),
+		MaxFloat, target)
+}
+
+// MinMaxFloatOf returns `MinMaxFloat` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(func(A) float64, []A) (float64, float64)
+func MinMaxFloatOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) float64, []ty.A) (float64, float64)),
+		MinMaxFloat, target)
+}
+
+// SumIntOf returns `SumInt` specialized to `target`, which must be a pointer to
+// a nil function whose type is an instance of
+//
+//	func(func(A) int64, []A) int64
+func SumIntOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) int64, []ty.A) int64),
+		SumInt, target)
+}
+
+// SumFloatOf returns `SumFloat` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(func(A) float64, []A) float64
+func SumFloatOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) float64, []ty.A) float64),
+		SumFloat, target)
+}
+
+// MinOf returns `Min` specialized to `target`, which must be a pointer to a nil
+// function whose type is an instance of
+//
+//	func(func(A) B, []A) (B, bool)
+func MinOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) ty.B, []ty.A) (ty.B, bool)),
+		Min, target)
+}
+
+// MaxOf returns `Max` specialized to `target`, which must be a pointer to a nil
+// function whose type is an instance of
+//
+//	func(func(A) B, []A) (B, bool)
+func MaxOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) ty.B, []ty.A) (ty.B, bool)),
+		Max, target)
+}
+
+// MinMaxOf returns `MinMax` specialized to `target`, which must be a pointer to
+// a nil function whose type is an instance of
+//
+//	func(func(A) B, []A) (B, B, bool)
+func MinMaxOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) ty.B, []ty.A) (ty.B, ty.B, bool)),
+		MinMax, target)
+}
+
+// SumOf returns `Sum` specialized to `target`, which must be a pointer to a nil
+// function whose type is an instance of
+//
+//	func(func(A) B, []A) B
+func SumOf(target interface{}) interface{} {
+	return ty.Specialize(new(func(func(ty.A) ty.B, []ty.A) ty.B), Sum, target)
+}
+
+// MinByOf returns `MinBy` specialized to `target`, which must be a pointer to a
+// nil function whose type is an instance of
+//
+//	func(func(A) B, []A) (A, bool)
+func MinByOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) ty.B, []ty.A) (ty.A, bool)),
+		MinBy, target)
+}
+
+// MaxByOf returns `MaxBy` specialized to `target`, which must be a pointer to a
+// nil function whose type is an instance of
+//
+//	func(func(A) B, []A) (A, bool)
+func MaxByOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) ty.B, []ty.A) (ty.A, bool)),
+		MaxBy, target)
+}
+
+// ShuffleGenOf returns `ShuffleGen` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func([]A, *rand.Rand)
+func ShuffleGenOf(target interface{}) interface{} {
+	return ty.Specialize(new(func([]ty.A, *rand.Rand)), ShuffleGen, target)
+}
+
+// ShuffleOf returns `Shuffle` specialized to `target`, which must be a pointer
+// to a nil function whose type is an instance of
+//
+//	func([]A)
+func ShuffleOf(target interface{}) interface{} {
+	return ty.Specialize(new(func([]ty.A)), Shuffle, target)
+}
+
+// SampleOf returns `Sample` specialized to `target`, which must be a pointer to
+// a nil function whose type is an instance of
+//
+//	func([]A, int) []A
+func SampleOf(target interface{}) interface{} {
+	return ty.Specialize(new(func([]ty.A, int) []ty.A), Sample, target)
+}
+
+// SampleGenOf returns `SampleGen` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func([]A, int, *rand.Rand) []A
+func SampleGenOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func([]ty.A, int, *rand.Rand) []ty.A),
+		SampleGen, target)
+}
+
+// SampleReplaceOf returns `SampleReplace` specialized to `target`, which must
+// be a pointer to a nil function whose type is an instance of
+//
+//	func([]A, int) []A
+func SampleReplaceOf(target interface{}) interface{} {
+	return ty.Specialize(new(func([]ty.A, int) []ty.A), SampleReplace, target)
+}
+
+// SampleReplaceGenOf returns `SampleReplaceGen` specialized to `target`, which
+// must be a pointer to a nil function whose type is an instance of
+//
+//	func([]A, int, *rand.Rand) []A
+func SampleReplaceGenOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func([]ty.A, int, *rand.Rand) []ty.A),
+		SampleReplaceGen, target)
+}
+
+// SampleWeightedOf returns `SampleWeighted` specialized to `target`, which must
+// be a pointer to a nil function whose type is an instance of
+//
+//	func(func(A) float64, []A, int) []A
+func SampleWeightedOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) float64, []ty.A, int) []ty.A),
+		SampleWeighted, target)
+}
+
+// SampleWeightedGenOf returns `SampleWeightedGen` specialized to `target`,
+// which must be a pointer to a nil function whose type is an instance of
+//
+//	func(func(A) float64, []A, int, *rand.Rand) []A
+func SampleWeightedGenOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) float64, []ty.A, int, *rand.Rand) []ty.A),
+		SampleWeightedGen, target)
+}
+
+// SampleChanOf returns `SampleChan` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(<-chan A, int) []A
+func SampleChanOf(target interface{}) interface{} {
+	return ty.Specialize(new(func(<-chan ty.A, int) []ty.A), SampleChan, target)
+}
+
+// SampleChanGenOf returns `SampleChanGen` specialized to `target`, which must
+// be a pointer to a nil function whose type is an instance of
+//
+//	func(<-chan A, int, *rand.Rand) []A
+func SampleChanGenOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(<-chan ty.A, int, *rand.Rand) []ty.A),
+		SampleChanGen, target)
+}
+
+// SetOf returns `Set` specialized to `target`, which must be a pointer to a nil
+// function whose type is an instance of
+//
+//	func([]A) map[A]bool
+func SetOf(target interface{}) interface{} {
+	return ty.Specialize(new(func([]ty.A) map[ty.A]bool), Set, target)
+}
+
+// SetByOf returns `SetBy` specialized to `target`, which must be a pointer to a
+// nil function whose type is an instance of
+//
+//	func([]A) []A
+func SetByOf(target interface{}) interface{} {
+	return ty.Specialize(new(func([]ty.A) []ty.A), SetBy, target)
+}
+
+// UnionOf returns `Union` specialized to `target`, which must be a pointer to a
+// nil function whose type is an instance of
+//
+//	func(map[A]bool, map[A]bool) map[A]bool
+func UnionOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(map[ty.A]bool, map[ty.A]bool) map[ty.A]bool),
+		Union, target)
+}
+
+// IntersectionOf returns `Intersection` specialized to `target`, which must be
+// a pointer to a nil function whose type is an instance of
+//
+//	func(map[A]bool, map[A]bool) map[A]bool
+func IntersectionOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(map[ty.A]bool, map[ty.A]bool) map[ty.A]bool),
+		Intersection, target)
+}
+
+// DifferenceOf returns `Difference` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(map[A]bool, map[A]bool) map[A]bool
+func DifferenceOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(map[ty.A]bool, map[ty.A]bool) map[ty.A]bool),
+		Difference, target)
+}
+
+// UniqOf returns `Uniq` specialized to `target`, which must be a pointer to a
+// nil function whose type is an instance of
+//
+//	func([]A) []A
+func UniqOf(target interface{}) interface{} {
+	return ty.Specialize(new(func([]ty.A) []ty.A), Uniq, target)
+}
+
+// UniqByOf returns `UniqBy` specialized to `target`, which must be a pointer to
+// a nil function whose type is an instance of
+//
+//	func(func(A) B, []A) []A
+func UniqByOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) ty.B, []ty.A) []ty.A),
+		UniqBy, target)
+}
+
+// FrequenciesOf returns `Frequencies` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func([]A) map[A]int
+func FrequenciesOf(target interface{}) interface{} {
+	return ty.Specialize(new(func([]ty.A) map[ty.A]int), Frequencies, target)
+}
+
+// CountByOf returns `CountBy` specialized to `target`, which must be a pointer
+// to a nil function whose type is an instance of
+//
+//	func(func(A) B, []A) map[B]int
+func CountByOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) ty.B, []ty.A) map[ty.B]int),
+		CountBy, target)
+}
+
+// DuplicatesOf returns `Duplicates` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func([]A) []A
+func DuplicatesOf(target interface{}) interface{} {
+	return ty.Specialize(new(func([]ty.A) []ty.A), Duplicates, target)
+}
+
+// CompactOf returns `Compact` specialized to `target`, which must be a pointer
+// to a nil function whose type is an instance of
+//
+//	func([]A) []A
+func CompactOf(target interface{}) interface{} {
+	return ty.Specialize(new(func([]ty.A) []ty.A), Compact, target)
+}
+
+// QuickSortOf returns `QuickSort` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(func(A, A) bool, []A) []A
+func QuickSortOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A, ty.A) bool, []ty.A) []ty.A),
+		QuickSort, target)
+}
+
+// SortOf returns `Sort` specialized to `target`, which must be a pointer to a
+// nil function whose type is an instance of
+//
+//	func(func(A, A) bool, []A)
+func SortOf(target interface{}) interface{} {
+	return ty.Specialize(new(func(func(ty.A, ty.A) bool, []ty.A)), Sort, target)
+}
+
+// SortStableOf returns `SortStable` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(func(A, A) bool, []A)
+func SortStableOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A, ty.A) bool, []ty.A)),
+		SortStable, target)
+}
+
+// SortByOf returns `SortBy` specialized to `target`, which must be a pointer to
+// a nil function whose type is an instance of
+//
+//	func(func(A) B, []A) []A
+func SortByOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A) ty.B, []ty.A) []ty.A),
+		SortBy, target)
+}
+
+// ThenByOf returns `ThenBy` specialized to `target`, which must be a pointer to
+// a nil function whose type is an instance of
+//
+//	func(func(A, A) bool, func(A, A) bool) func(A, A) bool
+func ThenByOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A, ty.A) bool, func(ty.A, ty.A) bool) func(ty.A, ty.A) bool),
+		ThenBy, target)
+}
+
+// IsSortedOf returns `IsSorted` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(func(A, A) bool, []A) bool
+func IsSortedOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A, ty.A) bool, []ty.A) bool),
+		IsSorted, target)
+}
+
+// LowerBoundOf returns `LowerBound` specialized to `target`, which must be a
+// pointer to a nil function whose type is an instance of
+//
+//	func(func(A, A) bool, []A, A) int
+func LowerBoundOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A, ty.A) bool, []ty.A, ty.A) int),
+		LowerBound, target)
+}
+
+// BinarySearchOf returns `BinarySearch` specialized to `target`, which must be
+// a pointer to a nil function whose type is an instance of
+//
+//	func(func(A, A) bool, []A, A) (int, bool)
+func BinarySearchOf(target interface{}) interface{} {
+	return ty.Specialize(
+		new(func(func(ty.A, ty.A) bool, []ty.A, ty.A) (int, bool)),
+		BinarySearch, target)
+}
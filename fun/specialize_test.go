@@ -0,0 +1,209 @@
+package fun
+
+import (
+	"math/rand"
+	"path"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/ty"
+)
+
+func TestSpecializeAll(t *testing.T) {
+	targets := []struct {
+		of     func(interface{}) interface{}
+		target interface{}
+	}{
+		{AsyncChanOf, new(func(*chan int) (chan<- int, <-chan int))},
+		{CycleEachOf, new(func(func(int), []int, int))},
+		{CycleMapOf, new(func(func(int) string, []int, int) []string)},
+		{EqualOf, new(func(int, int) bool)},
+		{CompareOf, new(func(int, int) int)},
+		{DeepHashOf, new(func(int) uint64)},
+		{MemoOf, new(func(func(int) string) func(int) string)},
+		{MemoByOf, new(func(func(int) string) func(int) string)},
+		{ComposeOf, new(
+			func(func(string) bool, func(int) string) func(int) bool)},
+		{Curry2Of, new(
+			func(func(int, string) bool) func(int) func(string) bool)},
+		{Uncurry2Of, new(
+			func(func(int) func(string) bool) func(int, string) bool)},
+		{FlipOf, new(func(func(int, string) bool) func(string, int) bool)},
+		{OnceOf, new(func(func(int) int) func(int) int)},
+		{DebounceOf, new(func(func(int) int, time.Duration) func(int) int)},
+		{ThrottleOf, new(func(func(int) int, time.Duration) func(int) int)},
+		{RetryOf, new(func(func(int) int, RetryPolicy) func(int) int)},
+		{MapOf, new(func(func(int) string, []int) []string)},
+		{MapIndexedOf, new(func(func(int, int) string, []int) []string)},
+		{FlatMapOf, new(func(func(int) []string, []int) []string)},
+		{UnfoldOf, new(func(func(string) (int, string, bool), string) []int)},
+		{FilterOf, new(func(func(int) bool, []int) []int)},
+		{FilterIndexedOf, new(func(func(int, int) bool, []int) []int)},
+		{FoldlOf, new(func(func(int, string) string, string, []int) string)},
+		{FoldrOf, new(func(func(int, string) string, string, []int) string)},
+		{ScanlOf, new(func(func(int, string) string, string, []int) []string)},
+		{ScanrOf, new(func(func(int, string) string, string, []int) []string)},
+		{ReduceOf, new(func(func(int, int) int, []int) (int, bool))},
+		{ConcatOf, new(func([][]int) []int)},
+		{ReverseOf, new(func([]int) []int)},
+		{CopyOf, new(func([]int) []int)},
+		{ParMapOf, new(func(func(int) string, []int) []string)},
+		{ParMapNOf, new(func(func(int) string, []int, int) []string)},
+		{EachOf, new(func(func(int), []int))},
+		{EachIndexedOf, new(func(func(int, int), []int))},
+		{GroupByOf, new(func(func(int) string, []int) map[string][]int)},
+		{GroupByKeyOf, new(func(func(int) string, []int) ([]string, [][]int))},
+		{ZipOf, new(func([]int, []int) []int)},
+		{PartitionOf, new(func(func(int) bool, []int) ([]int, []int))},
+		{DropOf, new(func(func(int) bool, []int) []int)},
+		{TakeOf, new(func(func(int) bool, []int) []int)},
+		{AllOf, new(func(func(int) bool, []int) bool)},
+		{AnyOf, new(func(func(int) bool, []int) bool)},
+		{CountOf, new(func(func(int) bool, []int) int)},
+		{DetectOf, new(func(func(int) bool, []int) int)},
+		{NoneOf, new(func(func(int) bool, []int) bool)},
+		{OneOf, new(func(func(int) bool, []int) bool)},
+		{ReplaceOf, new(func([]int, []int) []int)},
+		{ChunkOf, new(func([]int, int) [][]int)},
+		{WindowOf, new(func([]int, int, int) [][]int)},
+		{SplitAtOf, new(func([]int, int) ([]int, []int))},
+		{SplitWhenOf, new(func(func(int) bool, []int) [][]int)},
+		{InterleaveOf, new(func([][]int) []int)},
+		{TransposeOf, new(func([][]int) [][]int)},
+		{RotateOf, new(func([]int, int) []int)},
+		{TakeNOf, new(func([]int, int) []int)},
+		{DropNOf, new(func([]int, int) []int)},
+		{ZipWithOf, new(func(func(int, string) bool, []int, []string) []bool)},
+		{ZipPairsOf, new(
+			func([]int, []string) []struct {
+				First  int
+				Second string
+			})},
+		{KeysOf, new(func(map[string]int) []string)},
+		{ValuesOf, new(func(map[string]int) []int)},
+		{MapMergeOf, new(
+			func(map[int]string, map[int]string,
+				func(int, string, string) string) map[int]string)},
+		{MapFilterOf, new(
+			func(func(string, int) bool, map[string]int) map[string]int)},
+		{MapValuesOf, new(func(func(int) bool, map[string]int) map[string]bool)},
+		{MapKeysOf, new(func(func(int) bool, map[int]string) map[bool]string)},
+		{InvertOf, new(func(map[int]string) map[string]int)},
+		{EntriesOf, new(
+			func(map[int]string) []struct {
+				Key   int
+				Value string
+			})},
+		{SortedKeysOf, new(func(func(string, string) bool, map[string]int) []string)},
+		{MinIntOf, new(func(func(int) int64, []int) int64)},
+		{MaxIntOf, new(func(func(int) int64, []int) int64)},
+		{MinMaxIntOf, new(func(func(int) int64, []int) (int64, int64))},
+		{MinFloatOf, new(func(func(int) float64, []int) float64)},
+		{MaxFloatOf, new(func(func(int) float64, []int) float64)},
+		{MinMaxFloatOf, new(func(func(int) float64, []int) (float64, float64))},
+		{SumIntOf, new(func(func(int) int64, []int) int64)},
+		{SumFloatOf, new(func(func(int) float64, []int) float64)},
+		{MinOf, new(func(func(int) string, []int) (string, bool))},
+		{MaxOf, new(func(func(int) string, []int) (string, bool))},
+		{MinMaxOf, new(func(func(int) string, []int) (string, string, bool))},
+		{SumOf, new(func(func(int) string, []int) string)},
+		{MinByOf, new(func(func(int) string, []int) (int, bool))},
+		{MaxByOf, new(func(func(int) string, []int) (int, bool))},
+		{ShuffleGenOf, new(func([]int, *rand.Rand))},
+		{ShuffleOf, new(func([]int))},
+		{SampleOf, new(func([]int, int) []int)},
+		{SampleGenOf, new(func([]int, int, *rand.Rand) []int)},
+		{SampleReplaceOf, new(func([]int, int) []int)},
+		{SampleReplaceGenOf, new(func([]int, int, *rand.Rand) []int)},
+		{SampleWeightedOf, new(func(func(int) float64, []int, int) []int)},
+		{SampleWeightedGenOf, new(
+			func(func(int) float64, []int, int, *rand.Rand) []int)},
+		{SampleChanOf, new(func(<-chan int, int) []int)},
+		{SampleChanGenOf, new(func(<-chan int, int, *rand.Rand) []int)},
+		{SetOf, new(func([]int) map[int]bool)},
+		{SetByOf, new(func([]int) []int)},
+		{UnionOf, new(func(map[int]bool, map[int]bool) map[int]bool)},
+		{IntersectionOf, new(func(map[int]bool, map[int]bool) map[int]bool)},
+		{DifferenceOf, new(func(map[int]bool, map[int]bool) map[int]bool)},
+		{UniqOf, new(func([]int) []int)},
+		{UniqByOf, new(func(func(int) string, []int) []int)},
+		{FrequenciesOf, new(func([]int) map[int]int)},
+		{CountByOf, new(func(func(int) string, []int) map[string]int)},
+		{DuplicatesOf, new(func([]int) []int)},
+		{CompactOf, new(func([]int) []int)},
+		{QuickSortOf, new(func(func(int, int) bool, []int) []int)},
+		{SortOf, new(func(func(int, int) bool, []int))},
+		{SortStableOf, new(func(func(int, int) bool, []int))},
+		{SortByOf, new(func(func(int) string, []int) []int)},
+		{ThenByOf, new(func(func(int, int) bool, func(int, int) bool) func(
+			int, int) bool)},
+		{IsSortedOf, new(func(func(int, int) bool, []int) bool)},
+		{LowerBoundOf, new(func(func(int, int) bool, []int, int) int)},
+		{BinarySearchOf, new(func(func(int, int) bool, []int, int) (int, bool))},
+	}
+	specialized := make(map[string]bool)
+	for _, test := range targets {
+		f := test.of(test.target)
+		want := reflect.TypeOf(test.target).Elem()
+		if got := reflect.TypeOf(f); got != want {
+			t.Errorf("Expected specialized type '%s' but got '%s'.", want, got)
+		}
+		name := runtime.FuncForPC(reflect.ValueOf(test.of).Pointer()).Name()
+		specialized[strings.TrimSuffix(path.Ext(name)[1:], "Of")] = true
+	}
+	for name := range signatures {
+		if name == "Unzip" || name == "FromEntries" {
+			continue
+		}
+		if !specialized[name] {
+			t.Errorf("%s has a registered signature but no %sOf.", name, name)
+		}
+	}
+}
+
+func TestSpecializeCall(t *testing.T) {
+	mapItoa := MapOf(
+		new(func(func(int) string, []int) []string),
+	).(func(func(int) string, []int) []string)
+	assertDeep(t, mapItoa(strconv.Itoa, []int{1, 2}), []string{"1", "2"})
+
+	reduce := ReduceOf(
+		new(func(func(int, int) int, []int) (int, bool)),
+	).(func(func(int, int) int, []int) (int, bool))
+	sum, ok := reduce(func(a, b int) int { return a + b }, []int{1, 2, 3})
+	assertDeep(t, sum, 6)
+	assertDeep(t, ok, true)
+
+	detect := DetectOf(
+		new(func(func(string) bool, []string) string),
+	).(func(func(string) bool, []string) string)
+	none := func(s string) bool { return false }
+	assertDeep(t, detect(none, []string{"a"}), "")
+
+	sumLen := SumIntOf(
+		new(func(func(string) int64, []string) int64),
+	).(func(func(string) int64, []string) int64)
+	length := func(s string) int64 { return int64(len(s)) }
+	assertDeep(t, sumLen(length, []string{"a", "bc"}), int64(3))
+
+	asyncChan := AsyncChanOf(
+		new(func(*chan int) (chan<- int, <-chan int)),
+	).(func(*chan int) (chan<- int, <-chan int))
+	send, recv := asyncChan(new(chan int))
+	send <- 1
+	close(send)
+	assertDeep(t, <-recv, 1)
+}
+
+func TestSpecializeMismatch(t *testing.T) {
+	defer func() {
+		if _, ok := recover().(ty.TypeError); !ok {
+			t.Fatal("Specializing to a mismatched type did not panic.")
+		}
+	}()
+	MapOf(new(func(func(int) string, []int) []int))
+}
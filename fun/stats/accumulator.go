@@ -0,0 +1,144 @@
+package stats
+
+import (
+	"math"
+)
+
+// Accumulator computes summary statistics of a stream of numbers without
+// keeping them in memory. The zero value is an empty accumulator ready to
+// use.
+//
+// The mean and variance are maintained with Welford's algorithm and the sum
+// with Kahan-Babuska (Neumaier) compensated summation.
+type Accumulator struct {
+	n        int
+	mean, m2 float64
+	sum      kahan
+	min, max float64
+}
+
+// Add adds `x` to the accumulator.
+func (acc *Accumulator) Add(x float64) {
+	acc.n++
+	if acc.n == 1 {
+		acc.min, acc.max = x, x
+	} else {
+		acc.min, acc.max = math.Min(acc.min, x), math.Max(acc.max, x)
+	}
+	acc.sum.add(x)
+
+	delta := x - acc.mean
+	acc.mean += delta / float64(acc.n)
+	acc.m2 += delta * (x - acc.mean)
+}
+
+// Merge adds all numbers added to `other` to the accumulator, as if they
+// had been added with `Add`. This makes it possible to compute statistics
+// of parts of a list concurrently and combine them.
+func (acc *Accumulator) Merge(other *Accumulator) {
+	if other.n == 0 {
+		return
+	}
+	if acc.n == 0 {
+		*acc = *other
+		return
+	}
+
+	// Chan et al.'s parallel algorithm for combining variances.
+	n := acc.n + other.n
+	delta := other.mean - acc.mean
+	acc.m2 += other.m2 +
+		delta*delta*float64(acc.n)*float64(other.n)/float64(n)
+	acc.mean += delta * float64(other.n) / float64(n)
+	acc.n = n
+	acc.sum.add(other.sum.sum)
+	acc.sum.add(other.sum.c)
+	acc.min, acc.max = math.Min(acc.min, other.min), math.Max(acc.max, other.max)
+}
+
+// Count returns the number of numbers added to the accumulator.
+func (acc *Accumulator) Count() int {
+	return acc.n
+}
+
+// Sum returns the sum of the numbers added to the accumulator.
+func (acc *Accumulator) Sum() float64 {
+	return acc.sum.result()
+}
+
+// Mean returns the arithmetic mean of the numbers added to the accumulator,
+// or NaN if there are none.
+func (acc *Accumulator) Mean() float64 {
+	if acc.n == 0 {
+		return math.NaN()
+	}
+	return acc.mean
+}
+
+// Variance returns the sample variance of the numbers added to the
+// accumulator, or NaN if there are fewer than two.
+func (acc *Accumulator) Variance() float64 {
+	if acc.n < 2 {
+		return math.NaN()
+	}
+	return acc.m2 / float64(acc.n-1)
+}
+
+// PopVariance returns the population variance of the numbers added to the
+// accumulator, or NaN if there are none.
+func (acc *Accumulator) PopVariance() float64 {
+	if acc.n == 0 {
+		return math.NaN()
+	}
+	return acc.m2 / float64(acc.n)
+}
+
+// StdDev returns the sample standard deviation of the numbers added to the
+// accumulator, or NaN if there are fewer than two.
+func (acc *Accumulator) StdDev() float64 {
+	return math.Sqrt(acc.Variance())
+}
+
+// PopStdDev returns the population standard deviation of the numbers added
+// to the accumulator, or NaN if there are none.
+func (acc *Accumulator) PopStdDev() float64 {
+	return math.Sqrt(acc.PopVariance())
+}
+
+// Min returns the smallest number added to the accumulator, or NaN if there
+// are none.
+func (acc *Accumulator) Min() float64 {
+	if acc.n == 0 {
+		return math.NaN()
+	}
+	return acc.min
+}
+
+// Max returns the largest number added to the accumulator, or NaN if there
+// are none.
+func (acc *Accumulator) Max() float64 {
+	if acc.n == 0 {
+		return math.NaN()
+	}
+	return acc.max
+}
+
+// kahan is a running sum using Kahan-Babuska (Neumaier) compensated
+// summation. `c` accumulates the low order bits lost by `sum`.
+type kahan struct {
+	sum, c float64
+}
+
+func (k *kahan) add(x float64) {
+	t := k.sum + x
+	if math.Abs(k.sum) >= math.Abs(x) {
+		k.c += (k.sum - t) + x
+	} else {
+		k.c += (x - t) + k.sum
+	}
+	k.sum = t
+}
+
+func (k *kahan) result() float64 {
+	return k.sum + k.c
+}
@@ -0,0 +1,26 @@
+/*
+Package stats provides type parametric functions for computing summary
+statistics over lists of any type.
+
+Every function takes an extractor `f` with a parametric type
+`func(A) float64` which returns the number to use for each element of a list.
+This makes it possible to compute statistics over a field of a list of
+structs without building an intermediate `[]float64`:
+
+	type Album struct {
+		Title  string
+		Length float64
+	}
+	length := func(a Album) float64 { return a.Length }
+	avg := stats.Mean(length, albums)
+
+All computations use numerically stable algorithms. Sums use Kahan-Babuska
+(Neumaier) compensated summation and variances use Welford's algorithm.
+
+Functions that are undefined for an empty list (e.g., `Mean` or `Median`)
+return NaN when given one.
+
+If the entire list isn't available at once, an `Accumulator` computes the
+same statistics one value at a time.
+*/
+package stats
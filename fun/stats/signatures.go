@@ -0,0 +1,28 @@
+package stats
+
+import (
+	"github.com/BurntSushi/ty"
+)
+
+// The parametric types of the functions in this package are registered with
+// `ty.RegisterSignature`, qualified by the package name (e.g., `stats.Sum`),
+// so that tools can list them and type errors can print them.
+func init() {
+	for name, sig := range signatures {
+		ty.RegisterSignature("stats."+name, sig)
+	}
+}
+
+var signatures = map[string]interface{}{
+	"Sum":         new(func(func(ty.A) float64, []ty.A) float64),
+	"Mean":        new(func(func(ty.A) float64, []ty.A) float64),
+	"Variance":    new(func(func(ty.A) float64, []ty.A) float64),
+	"PopVariance": new(func(func(ty.A) float64, []ty.A) float64),
+	"StdDev":      new(func(func(ty.A) float64, []ty.A) float64),
+	"PopStdDev":   new(func(func(ty.A) float64, []ty.A) float64),
+	"Accumulate":  new(func(func(ty.A) float64, []ty.A) *Accumulator),
+	"Median":      new(func(func(ty.A) float64, []ty.A) float64),
+	"Percentile":  new(func(func(ty.A) float64, []ty.A, float64) float64),
+	"Mode":        new(func(func(ty.A) float64, []ty.A) []float64),
+	"Histogram":   new(func(func(ty.A) float64, []ty.A, int) []Bucket),
+}
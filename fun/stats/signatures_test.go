@@ -0,0 +1,33 @@
+package stats
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/BurntSushi/ty"
+)
+
+func TestSignatures(t *testing.T) {
+	funcs := map[string]interface{}{
+		"Sum": Sum, "Mean": Mean, "Variance": Variance,
+		"PopVariance": PopVariance, "StdDev": StdDev, "PopStdDev": PopStdDev,
+		"Accumulate": Accumulate, "Median": Median, "Percentile": Percentile,
+		"Mode": Mode, "Histogram": Histogram,
+	}
+	for name, f := range funcs {
+		sig, ok := ty.LookupSignature("stats." + name)
+		if !ok {
+			t.Errorf("%s is not registered.", name)
+			continue
+		}
+		typ := reflect.TypeOf(f)
+		if typ.NumIn() != len(sig.Params()) ||
+			typ.NumOut() != len(sig.Results()) {
+			t.Errorf("%s does not match the arity of '%s'.", sig, typ)
+		}
+	}
+	if len(funcs) != len(signatures) {
+		t.Errorf("Expected %d signatures but %d are registered.",
+			len(funcs), len(signatures))
+	}
+}
@@ -0,0 +1,261 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+
+	"github.com/BurntSushi/ty"
+)
+
+// Sum has a parametric type:
+//
+//	func Sum(f func(A) float64, xs []A) float64
+//
+// Sum returns the sum of the values returned from `f` for each element of
+// `xs`, using compensated summation to minimize rounding error.
+func Sum(f, xs interface{}) float64 {
+	chk := ty.Check(
+		new(func(func(ty.A) float64, []ty.A)),
+		f, xs)
+	vf, vxs := chk.Args[0], chk.Args[1]
+
+	var sum kahan
+	xsLen := vxs.Len()
+	for i := 0; i < xsLen; i++ {
+		sum.add(call1(vf, vxs.Index(i)).Float())
+	}
+	return sum.result()
+}
+
+// Mean has a parametric type:
+//
+//	func Mean(f func(A) float64, xs []A) float64
+//
+// Mean returns the arithmetic mean of the values returned from `f` for each
+// element of `xs`, or NaN if `xs` is empty.
+func Mean(f, xs interface{}) float64 {
+	return Accumulate(f, xs).Mean()
+}
+
+// Variance has a parametric type:
+//
+//	func Variance(f func(A) float64, xs []A) float64
+//
+// Variance returns the sample variance of the values returned from `f` for
+// each element of `xs`, or NaN if `xs` has fewer than two elements.
+func Variance(f, xs interface{}) float64 {
+	return Accumulate(f, xs).Variance()
+}
+
+// PopVariance has a parametric type:
+//
+//	func PopVariance(f func(A) float64, xs []A) float64
+//
+// PopVariance returns the population variance of the values returned from
+// `f` for each element of `xs`, or NaN if `xs` is empty.
+func PopVariance(f, xs interface{}) float64 {
+	return Accumulate(f, xs).PopVariance()
+}
+
+// StdDev has a parametric type:
+//
+//	func StdDev(f func(A) float64, xs []A) float64
+//
+// StdDev returns the sample standard deviation of the values returned from
+// `f` for each element of `xs`, or NaN if `xs` has fewer than two elements.
+func StdDev(f, xs interface{}) float64 {
+	return Accumulate(f, xs).StdDev()
+}
+
+// PopStdDev has a parametric type:
+//
+//	func PopStdDev(f func(A) float64, xs []A) float64
+//
+// PopStdDev returns the population standard deviation of the values returned
+// from `f` for each element of `xs`, or NaN if `xs` is empty.
+func PopStdDev(f, xs interface{}) float64 {
+	return Accumulate(f, xs).PopStdDev()
+}
+
+// Accumulate has a parametric type:
+//
+//	func Accumulate(f func(A) float64, xs []A) *Accumulator
+//
+// Accumulate returns an accumulator with the values returned from `f` for
+// each element of `xs` added to it.
+func Accumulate(f, xs interface{}) *Accumulator {
+	chk := ty.Check(
+		new(func(func(ty.A) float64, []ty.A)),
+		f, xs)
+	vf, vxs := chk.Args[0], chk.Args[1]
+
+	acc := new(Accumulator)
+	xsLen := vxs.Len()
+	for i := 0; i < xsLen; i++ {
+		acc.Add(call1(vf, vxs.Index(i)).Float())
+	}
+	return acc
+}
+
+// Median has a parametric type:
+//
+//	func Median(f func(A) float64, xs []A) float64
+//
+// Median returns the median of the values returned from `f` for each element
+// of `xs`, or NaN if `xs` is empty. When `xs` has an even number of elements,
+// the median is the mean of the two middle values.
+//
+// Median panics if a value is NaN or infinite, since such values have no
+// meaningful rank.
+func Median(f, xs interface{}) float64 {
+	return Percentile(f, xs, 50)
+}
+
+// Percentile has a parametric type:
+//
+//	func Percentile(f func(A) float64, xs []A, p float64) float64
+//
+// Percentile returns the `p`th percentile of the values returned from `f` for
+// each element of `xs`, where `p` must be in the interval [0, 100]. When the
+// percentile falls between two values, it is linearly interpolated between
+// them. Percentile returns NaN if `xs` is empty.
+//
+// Percentile panics if a value is NaN or infinite, since such values have no
+// meaningful rank.
+func Percentile(f, xs interface{}, p float64) float64 {
+	chk := ty.Check(
+		new(func(func(ty.A) float64, []ty.A, float64)),
+		f, xs, p)
+	vf, vxs := chk.Args[0], chk.Args[1]
+
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		panic(fmt.Sprintf("percentile %v is not in the interval [0, 100]", p))
+	}
+	nums := sorted(vf, vxs)
+	if len(nums) == 0 {
+		return math.NaN()
+	}
+
+	rank := p / 100 * float64(len(nums)-1)
+	lo := int(math.Floor(rank))
+	if lo == len(nums)-1 {
+		return nums[lo]
+	}
+	frac := rank - float64(lo)
+	return nums[lo] + frac*(nums[lo+1]-nums[lo])
+}
+
+// Mode has a parametric type:
+//
+//	func Mode(f func(A) float64, xs []A) []float64
+//
+// Mode returns the values returned most often from `f` for each element of
+// `xs` in ascending order. There is more than one mode when several values
+// are returned equally often. Mode returns an empty list if `xs` is empty.
+//
+// Mode panics if a value is NaN or infinite.
+func Mode(f, xs interface{}) []float64 {
+	chk := ty.Check(
+		new(func(func(ty.A) float64, []ty.A)),
+		f, xs)
+	vf, vxs := chk.Args[0], chk.Args[1]
+
+	nums := sorted(vf, vxs)
+	modes := make([]float64, 0, 1)
+	best := 0
+	for i := 0; i < len(nums); {
+		j := i + 1
+		for j < len(nums) && nums[j] == nums[i] {
+			j++
+		}
+		switch count := j - i; {
+		case count > best:
+			best = count
+			modes = append(modes[:0], nums[i])
+		case count == best:
+			modes = append(modes, nums[i])
+		}
+		i = j
+	}
+	return modes
+}
+
+// Bucket is a half-open interval [Lo, Hi) of a histogram along with the
+// number of values that fell into it.
+type Bucket struct {
+	Lo, Hi float64
+	Count  int
+}
+
+// Histogram has a parametric type:
+//
+//	func Histogram(f func(A) float64, xs []A, buckets int) []Bucket
+//
+// Histogram divides the range between the smallest and largest values
+// returned from `f` for each element of `xs` into `buckets` intervals of
+// equal width and counts the values in each. The last bucket also includes
+// the largest value.
+//
+// If `xs` is empty, Histogram returns no buckets. Histogram panics if
+// `buckets` is less than 1 or if a value is NaN or infinite, since the
+// buckets could not have a finite width.
+func Histogram(f, xs interface{}, buckets int) []Bucket {
+	chk := ty.Check(
+		new(func(func(ty.A) float64, []ty.A, int)),
+		f, xs, buckets)
+	vf, vxs := chk.Args[0], chk.Args[1]
+
+	if buckets < 1 {
+		panic(fmt.Sprintf("cannot build a histogram with %d buckets", buckets))
+	}
+	nums := sorted(vf, vxs)
+	if len(nums) == 0 {
+		return []Bucket{}
+	}
+
+	min, max := nums[0], nums[len(nums)-1]
+	width := (max - min) / float64(buckets)
+	hist := make([]Bucket, buckets)
+	for i := range hist {
+		hist[i].Lo = min + float64(i)*width
+		hist[i].Hi = min + float64(i+1)*width
+	}
+	hist[buckets-1].Hi = max
+	for _, x := range nums {
+		i := buckets - 1
+		if width > 0 {
+			i = int((x - min) / width)
+			if i >= buckets {
+				i = buckets - 1
+			}
+		}
+		hist[i].Count++
+	}
+	return hist
+}
+
+// sorted returns the values returned from `vf` for each element of `vxs` in
+// ascending order. It panics if a value is NaN or infinite.
+func sorted(vf, vxs reflect.Value) []float64 {
+	xsLen := vxs.Len()
+	nums := make([]float64, xsLen)
+	for i := 0; i < xsLen; i++ {
+		nums[i] = call1(vf, vxs.Index(i)).Float()
+		if math.IsNaN(nums[i]) || math.IsInf(nums[i], 0) {
+			panic(fmt.Sprintf("value %v of element %d is not finite",
+				nums[i], i))
+		}
+	}
+	sort.Float64s(nums)
+	return nums
+}
+
+func call1(f reflect.Value, args ...reflect.Value) reflect.Value {
+	if f.IsNil() {
+		panic(ty.TypeError(fmt.Sprintf(
+			"Cannot call the nil function argument of type '%s'.", f.Type())))
+	}
+	return f.Call(args)[0]
+}
@@ -0,0 +1,161 @@
+package stats
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/ty"
+)
+
+type album struct {
+	title  string
+	length float64
+}
+
+var (
+	albums = []album{
+		{"Born to Run", 39.5},
+		{"Darkness", 42.9},
+		{"The River", 83.7},
+		{"Nebraska", 40.9},
+		{"Greetings", 37.1},
+	}
+	length = func(a album) float64 { return a.length }
+	id     = func(x float64) float64 { return x }
+)
+
+func TestSum(t *testing.T) {
+	assertClose(t, Sum(length, albums), 244.1)
+	assertDeep(t, Sum(id, []float64{}), 0.0)
+
+	// Naive summation loses the small values entirely.
+	nums := []float64{1e100, 1, -1e100, 1}
+	assertDeep(t, Sum(id, nums), 2.0)
+}
+
+func TestNilExtractor(t *testing.T) {
+	// A nil extractor is fine until it is called.
+	assertDeep(t, Sum(nil, []int{}), 0.0)
+	defer func() {
+		err, ok := recover().(ty.TypeError)
+		if !ok || !strings.Contains(err.Error(), "func(int) float64") {
+			t.Fatalf("Calling a nil extractor should panic with a TypeError "+
+				"naming the function type, but got %v", err)
+		}
+	}()
+	Sum(nil, []int{1})
+}
+
+func TestMeanVariance(t *testing.T) {
+	nums := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	assertDeep(t, Mean(id, nums), 5.0)
+	assertDeep(t, PopVariance(id, nums), 4.0)
+	assertDeep(t, PopStdDev(id, nums), 2.0)
+	assertClose(t, Variance(id, nums), 32.0/7)
+	assertClose(t, StdDev(id, nums), math.Sqrt(32.0/7))
+
+	assertNaN(t, Mean(id, []float64{}))
+	assertNaN(t, Variance(id, []float64{1}))
+	assertDeep(t, PopVariance(id, []float64{1}), 0.0)
+
+	// A large offset ruins the naive sum of squares formula.
+	shifted := []float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16}
+	assertDeep(t, Variance(id, shifted), 30.0)
+}
+
+func TestPercentile(t *testing.T) {
+	assertDeep(t, Median(length, albums), 40.9)
+	assertDeep(t, Median(id, []float64{4, 1, 3, 2}), 2.5)
+	assertDeep(t, Percentile(id, []float64{4, 1, 3, 2}, 0), 1.0)
+	assertDeep(t, Percentile(id, []float64{4, 1, 3, 2}, 100), 4.0)
+	assertDeep(t, Percentile(id, []float64{10, 20, 30, 40, 50}, 90), 46.0)
+	assertNaN(t, Median(id, []float64{}))
+}
+
+func TestMode(t *testing.T) {
+	assertDeep(t, Mode(id, []float64{1, 3, 2, 3, 1, 4}), []float64{1, 3})
+	assertDeep(t, Mode(id, []float64{5, 2, 5}), []float64{5})
+	assertDeep(t, Mode(id, []float64{}), []float64{})
+}
+
+func TestHistogram(t *testing.T) {
+	nums := []float64{0, 1, 2, 2.5, 5, 9.9, 10}
+	assertDeep(t, Histogram(id, nums, 2), []Bucket{
+		{0, 5, 4},
+		{5, 10, 3},
+	})
+	assertDeep(t, Histogram(id, []float64{3, 3}, 2), []Bucket{
+		{3, 3, 0},
+		{3, 3, 2},
+	})
+	assertDeep(t, Histogram(id, []float64{}, 2), []Bucket{})
+}
+
+func TestNotFinite(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	for _, nums := range [][]float64{{1, 2, nan}, {1, inf, 2}, {-inf}} {
+		assertPanics(t, func() { Median(id, nums) })
+		assertPanics(t, func() { Percentile(id, nums, 10) })
+		assertPanics(t, func() { Mode(id, nums) })
+		assertPanics(t, func() { Histogram(id, nums, 2) })
+	}
+}
+
+func TestAccumulator(t *testing.T) {
+	nums := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	acc := new(Accumulator)
+	for _, x := range nums {
+		acc.Add(x)
+	}
+	assertDeep(t, acc.Count(), 8)
+	assertDeep(t, acc.Sum(), 40.0)
+	assertDeep(t, acc.Mean(), 5.0)
+	assertDeep(t, acc.PopVariance(), 4.0)
+	assertDeep(t, acc.Min(), 2.0)
+	assertDeep(t, acc.Max(), 9.0)
+
+	left, right := Accumulate(id, nums[:3]), Accumulate(id, nums[3:])
+	left.Merge(right)
+	assertDeep(t, left.Count(), acc.Count())
+	assertDeep(t, left.Sum(), acc.Sum())
+	assertClose(t, left.Mean(), acc.Mean())
+	assertClose(t, left.Variance(), acc.Variance())
+	assertDeep(t, left.Min(), acc.Min())
+	assertDeep(t, left.Max(), acc.Max())
+
+	empty := new(Accumulator)
+	assertNaN(t, empty.Mean())
+	assertNaN(t, empty.Min())
+	empty.Merge(acc)
+	assertDeep(t, empty.Mean(), 5.0)
+}
+
+func assertDeep(t *testing.T, v1, v2 interface{}) {
+	if !reflect.DeepEqual(v1, v2) {
+		t.Fatalf("%v != %v", v1, v2)
+	}
+}
+
+func assertClose(t *testing.T, f1, f2 float64) {
+	if math.Abs(f1-f2) > 1e-9*math.Max(math.Abs(f1), math.Abs(f2)) {
+		t.Fatalf("%v != %v", f1, f2)
+	}
+}
+
+func assertPanics(t *testing.T, f func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic")
+		}
+	}()
+	f()
+}
+
+func assertNaN(t *testing.T, f float64) {
+	if !math.IsNaN(f) {
+		t.Fatalf("%v != NaN", f)
+	}
+}
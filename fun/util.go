@@ -1,7 +1,10 @@
 package fun
 
 import (
+	"fmt"
 	"reflect"
+
+	"github.com/BurntSushi/ty"
 )
 
 func zeroValue(typ reflect.Type) reflect.Value {
@@ -22,16 +25,37 @@ func (s swapper) swap(a, b reflect.Value) {
 }
 
 func call(f reflect.Value, args ...reflect.Value) {
+	assertCallable(f)
 	f.Call(args)
 }
 
 func call1(f reflect.Value, args ...reflect.Value) reflect.Value {
+	assertCallable(f)
 	return f.Call(args)[0]
 }
 
 func call2(f reflect.Value, args ...reflect.Value) (
 	reflect.Value, reflect.Value) {
 
+	assertCallable(f)
 	ret := f.Call(args)
 	return ret[0], ret[1]
 }
+
+// assertCallable panics with a `TypeError` if the function argument `f` is
+// nil, which `ty.Check` allows (e.g., for an untyped nil), rather than
+// letting `reflect` panic when it is called.
+func assertCallable(f reflect.Value) {
+	if f.IsNil() {
+		panic(ty.TypeError(fmt.Sprintf(
+			"Cannot call the nil function argument of type '%s'.", f.Type())))
+	}
+}
+
+// verified returns `v`, the only result of a parametric function, after
+// checking it against the return type inferred by `chk`. (See
+// `ty.Typed.VerifyReturns`.)
+func verified(chk *ty.Typed, v interface{}) interface{} {
+	chk.VerifyReturns(v)
+	return v
+}
@@ -0,0 +1,259 @@
+package ty
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Instance is an instance of a parametric type, such as `OrdMap<K, V>` in
+// the `data` package, with its type variables bound to Go types. The
+// methods of a parametric type check their arguments with the `Check`
+// method of its instance, which checks them against the bound types.
+//
+// For example, an ordered map from strings to integers might be given the
+// instance
+//
+//	inst := ty.NewInstance("OrdMap", map[reflect.Type]reflect.Type{
+//		reflect.TypeOf(ty.A{}): reflect.TypeOf(""),
+//		reflect.TypeOf(ty.B{}): reflect.TypeOf(0),
+//	})
+//
+// and its `Put` method might check a key and a value with
+//
+//	chk := inst.Check(new(func(ty.A, ty.B)), key, val)
+//
+// which panics with a `TypeError` unless `key` is a string and `val` is an
+// integer.
+type Instance struct {
+	name  string
+	tyenv tyenv
+
+	// methods caches the check of each method type given to `Check` in
+	// which every type variable is bound by the instance, or nil if some
+	// type variable in it is not.
+	mu      sync.RWMutex
+	methods map[reflect.Type]*method
+}
+
+// NewInstance returns an instance of the parametric type `name` in which
+// each type variable in `bindings` is bound to its Go type. `bindings` is
+// copied.
+//
+// NewInstance panics with a `TypeError` if a key in `bindings` is not a type
+// variable, or if a Go type in `bindings` contains type variables.
+func NewInstance(
+	name string,
+	bindings map[reflect.Type]reflect.Type,
+) *Instance {
+	env := make(tyenv, len(bindings))
+	for tyvar, typ := range bindings {
+		if tyvar == nil || !isTyvar(tyvar) {
+			ppe("Cannot bind '%v', which is not a type variable.", tyvar)
+		}
+		if typ == nil || hasTyvars(typ, nil) {
+			ppe("Cannot bind %s to '%v', which is not a Go type.",
+				tyvarName(tyvar), typ)
+		}
+		env[tyvar] = typ
+	}
+	return &Instance{
+		name:    name,
+		tyenv:   env,
+		methods: make(map[reflect.Type]*method),
+	}
+}
+
+// Name returns the name of the parametric type.
+func (inst *Instance) Name() string {
+	return inst.name
+}
+
+// Lookup returns the Go type that the type variable `tyvar` is bound to in
+// the instance. If `tyvar` is not bound, Lookup returns false.
+func (inst *Instance) Lookup(tyvar reflect.Type) (reflect.Type, bool) {
+	typ, ok := inst.tyenv[tyvar]
+	return typ, ok
+}
+
+// Equal returns true if `inst` and `other` are instances of the same
+// parametric type with the same type variables bound to the same types.
+func (inst *Instance) Equal(other *Instance) bool {
+	if inst.name != other.name || len(inst.tyenv) != len(other.tyenv) {
+		return false
+	}
+	for tyvar, typ := range inst.tyenv {
+		if other.tyenv[tyvar] != typ {
+			return false
+		}
+	}
+	return true
+}
+
+// Instantiate is just like the `Instantiate` function, except the type
+// variables in `sig` are replaced by the Go types they are bound to in the
+// instance.
+func (inst *Instance) Instantiate(sig interface{}) reflect.Type {
+	tsig := parametricType(sig)
+	return substitution{inst.tyenv, tsig, "type"}.tysubst(tsig)
+}
+
+// Check is just like the `Check` function, except the type variables bound
+// in the instance are bound before the arguments `as` are unified with the
+// parameter types of `f`. An argument whose type is inconsistent with the
+// instance is a type error, and the type of an untyped nil argument may be
+// inferred from the instance. Type variables that are not bound in the
+// instance are bound by the arguments, as usual.
+//
+// When every type variable in `f` is bound in the instance, Check compares
+// the types of the arguments with the parameter types of `f`, with the bound
+// types substituted in, which are computed once for each type of `f`.
+//
+// The type errors reported by Check include the instance, e.g.,
+// `OrdMap<string, int>`.
+func (inst *Instance) Check(f interface{}, as ...interface{}) *Typed {
+	return check(inst, f, as)
+}
+
+// String returns the name of the parametric type followed by the Go types
+// bound in the instance, ordered by the names of their type variables, e.g.,
+// `OrdMap<string, int>`.
+func (inst *Instance) String() string {
+	tyvars := inst.tyvars()
+	types := make([]string, len(tyvars))
+	for i, tyvar := range tyvars {
+		types[i] = inst.tyenv[tyvar].String()
+	}
+	return inst.name + "<" + strings.Join(types, ", ") + ">"
+}
+
+// tyvars returns the type variables bound in the instance, ordered by name.
+func (inst *Instance) tyvars() []reflect.Type {
+	tyvars := make([]reflect.Type, 0, len(inst.tyenv))
+	for tyvar := range inst.tyenv {
+		tyvars = append(tyvars, tyvar)
+	}
+	sort.Slice(tyvars, func(i, j int) bool {
+		return tyvarName(tyvars[i]) < tyvarName(tyvars[j])
+	})
+	return tyvars
+}
+
+// method is the check of a method type in which every type variable is
+// bound by an instance. Its arguments need not be unified: an argument
+// whose type is its parameter type, with the bound types substituted in,
+// is consistent with the instance.
+type method struct {
+	params []reflect.Type
+	c      *checked
+}
+
+// checkBound returns the check of the method type `tf` with `args` if every
+// type variable in `tf` is bound by the instance and each argument has its
+// parameter type, or is an untyped nil whose parameter type can be nil.
+// Otherwise, it returns nil, and the arguments must be unified.
+func (inst *Instance) checkBound(
+	tf reflect.Type,
+	args []reflect.Value,
+) *checked {
+	m := inst.method(tf)
+	if m == nil {
+		return nil
+	}
+	for i := range args {
+		if args[i].IsValid() {
+			if args[i].Type() != m.params[i] {
+				return nil
+			}
+		} else if !nillable(m.params[i]) {
+			return nil
+		}
+	}
+	return m.c
+}
+
+// method returns the cached check of the method type `tf`, or nil if some
+// type variable in it is not bound by the instance.
+func (inst *Instance) method(tf reflect.Type) *method {
+	inst.mu.RLock()
+	m, ok := inst.methods[tf]
+	inst.mu.RUnlock()
+	if ok {
+		return m
+	}
+
+	m = inst.newMethod(tf)
+	inst.mu.Lock()
+	if inst.methods == nil {
+		inst.methods = make(map[reflect.Type]*method)
+	}
+	inst.methods[tf] = m
+	inst.mu.Unlock()
+	return m
+}
+
+// newMethod substitutes the types bound by the instance in the method type
+// `tf`, or returns nil if some type variable in it is not bound or cannot be
+// substituted.
+func (inst *Instance) newMethod(tf reflect.Type) *method {
+	if !inst.tyenv.binds(tf) || !substitutable(tf) {
+		return nil
+	}
+	params := make([]reflect.Type, tf.NumIn())
+	for i := range params {
+		sub := substitution{inst.tyenv, tf.In(i), "parameter type"}
+		params[i] = sub.tysubst(tf.In(i))
+	}
+	returns := make([]reflect.Type, tf.NumOut())
+	for i := range returns {
+		sub := substitution{inst.tyenv, tf.Out(i), "return type"}
+		returns[i] = sub.tysubst(tf.Out(i))
+	}
+	return &method{
+		params: params,
+		c: &checked{
+			returns:  returns,
+			typeEnv:  inst.tyenv.byName(),
+			tyenv:    inst.tyenv,
+			nilTypes: params,
+		},
+	}
+}
+
+// substitutable returns true if every type variable in `t` is found in a
+// type that `substitution.tysubst` can create, i.e., not in an interface or
+// a struct with unexported fields.
+func substitutable(t reflect.Type) bool {
+	if isTyvar(t) || !hasTyvars(t, nil) {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Ptr, reflect.Slice:
+		return substitutable(t.Elem())
+	case reflect.Func:
+		for i := 0; i < t.NumIn(); i++ {
+			if !substitutable(t.In(i)) {
+				return false
+			}
+		}
+		for i := 0; i < t.NumOut(); i++ {
+			if !substitutable(t.Out(i)) {
+				return false
+			}
+		}
+	case reflect.Interface:
+		return false
+	case reflect.Map:
+		return substitutable(t.Key()) && substitutable(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" || !substitutable(f.Type) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+package ty_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/BurntSushi/ty"
+)
+
+func newTestInstance() *ty.Instance {
+	return ty.NewInstance("Pair", map[reflect.Type]reflect.Type{
+		tyA: reflect.TypeOf(""),
+		tyB: reflect.TypeOf(0),
+	})
+}
+
+func TestInstanceCheck(t *testing.T) {
+	inst := newTestInstance()
+
+	// Modifying the results of a check does not change the next one's.
+	for i := 0; i < 2; i++ {
+		chk := inst.Check(new(func(ty.A, ty.B) []ty.B), "a", 1)
+		if got, want := chk.Returns[0], reflect.TypeOf([]int{}); got != want {
+			t.Fatalf("Expected return type '%s' but got '%s'.", want, got)
+		}
+		chk.Returns[0] = reflect.TypeOf("")
+	}
+
+	// Type variables not bound by the instance are bound by the arguments.
+	chk := inst.Check(new(func(ty.A, ty.C) map[ty.A]ty.C), "a", 1.5)
+	want := reflect.TypeOf(map[string]float64{})
+	if got := chk.Returns[0]; got != want {
+		t.Fatalf("Expected return type '%s' but got '%s'.", want, got)
+	}
+
+	// Untyped nil arguments are given the types bound by the instance.
+	chk = inst.Check(new(func(func(ty.A) ty.B)), nil)
+	want = reflect.TypeOf(func(string) int { return 0 })
+	if got := chk.Args[0].Type(); got != want {
+		t.Fatalf("Expected argument type '%s' but got '%s'.", want, got)
+	}
+
+	// The arguments of a method type whose type variables are all bound by
+	// the instance are compared with its instantiated parameter types.
+	for i := 0; i < 2; i++ {
+		chk = inst.Check(new(func(ty.A, ty.B)), "a", 1)
+		if got := chk.Args[1].Interface(); got != 1 {
+			t.Fatalf("Expected argument 1 but got %v.", got)
+		}
+		assertTypeError(t, "of Pair<string, int>", func() {
+			inst.Check(new(func(ty.A, ty.B)), 1, 1)
+		})
+		assertTypeError(t, "expected type 'int' but got 'string'", func() {
+			inst.Check(new(func(ty.A, ty.B)), "a", "b")
+		})
+		assertTypeError(t, "Argument 2 of type 'int' cannot be nil", func() {
+			inst.Check(new(func(ty.A, ty.B)), "a", nil)
+		})
+	}
+}
+
+func TestInstanceExplain(t *testing.T) {
+	inst := newTestInstance()
+	chk := inst.Check(new(func(ty.A, []ty.C)), "a", []bool{})
+	want := "A = string, from instance Pair<string, int>\n" +
+		"B = int, from instance Pair<string, int>\n" +
+		"C = bool, from element of argument 2 '[]bool'\n"
+	if got := chk.Explain(); got != want {
+		t.Errorf("Expected explanation\n%s\nbut got\n%s", want, got)
+	}
+}
+
+func TestInstance(t *testing.T) {
+	inst := newTestInstance()
+	if got := inst.String(); got != "Pair<string, int>" {
+		t.Fatalf("Expected 'Pair<string, int>' but got '%s'.", got)
+	}
+	if typ, ok := inst.Lookup(tyB); !ok || typ != reflect.TypeOf(0) {
+		t.Fatalf("Expected B to be bound to 'int' but got '%v'.", typ)
+	}
+	if _, ok := inst.Lookup(reflect.TypeOf(ty.C{})); ok {
+		t.Fatal("Expected C to be unbound.")
+	}
+
+	want := reflect.TypeOf(map[string][]int{})
+	if got := inst.Instantiate(new(map[ty.A][]ty.B)); got != want {
+		t.Fatalf("Expected '%s' but got '%s'.", want, got)
+	}
+
+	if !inst.Equal(newTestInstance()) {
+		t.Fatal("Expected equal instances.")
+	}
+	other := ty.NewInstance("Pair", map[reflect.Type]reflect.Type{
+		tyA: reflect.TypeOf(""),
+		tyB: reflect.TypeOf(""),
+	})
+	if inst.Equal(other) {
+		t.Fatalf("Expected %s and %s to differ.", inst, other)
+	}
+
+	assertTypeError(t, "which is not a type variable", func() {
+		ty.NewInstance("Pair", map[reflect.Type]reflect.Type{
+			reflect.TypeOf(0): reflect.TypeOf(""),
+		})
+	})
+	assertTypeError(t, "which is not a Go type", func() {
+		ty.NewInstance("Pair", map[reflect.Type]reflect.Type{tyA: tyB})
+	})
+}
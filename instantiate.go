@@ -0,0 +1,64 @@
+package ty
+
+import (
+	"reflect"
+)
+
+// Instantiate returns the type given by `sig` with every type variable in it
+// replaced by the Go type it is bound to in `bindings`. `sig` is either a
+// `reflect.Type` or, like the `f` given to `Check`, a pointer to a nil value
+// of the parametric type. It may be any parametric type, including the
+// signature of a parametric function. For example,
+//
+//	tsig := Instantiate(
+//		new(func(ty.A) (map[ty.A]ty.B, []ty.A)),
+//		map[reflect.Type]reflect.Type{
+//			reflect.TypeOf(ty.A{}): reflect.TypeOf(""),
+//			reflect.TypeOf(ty.B{}): reflect.TypeOf(0),
+//		})
+//
+// returns the type of `func(string) (map[string]int, []string)`, whose
+// parameter and result types can be retrieved with `In` and `Out`.
+//
+// Unlike `Check`, no values are needed, which makes Instantiate useful for
+// constructing types that are only known at run time.
+//
+// Instantiate panics with a `TypeError` if a key in `bindings` is not a type
+// variable, if a type variable in `sig` is not bound, or if the type cannot
+// be constructed (see the restrictions documented for `Check`).
+func Instantiate(
+	sig interface{},
+	bindings map[reflect.Type]reflect.Type,
+) reflect.Type {
+	tsig := parametricType(sig)
+	for tyvar := range bindings {
+		if tyvar == nil || !isTyvar(tyvar) {
+			ppe("Cannot bind '%v', which is not a type variable.", tyvar)
+		}
+	}
+	return substitution{tyenv(bindings), tsig, "type"}.tysubst(tsig)
+}
+
+// parametricType returns the type given by `sig`, which is either a
+// `reflect.Type` or a pointer to a nil value of the type.
+func parametricType(sig interface{}) reflect.Type {
+	if tsig, ok := sig.(reflect.Type); ok {
+		return tsig
+	}
+	tsig := reflect.TypeOf(sig)
+	if tsig == nil || tsig.Kind() != reflect.Ptr {
+		ppe("The parametric type must be a reflect.Type or a pointer, "+
+			"but it is a '%v'.", tsig)
+	}
+	return tsig.Elem()
+}
+
+// Subst returns the type `t` with every type variable in it replaced by the
+// Go type it is bound to in `env`. Types without type variables are
+// returned unchanged.
+//
+// Subst panics with a `TypeError` if a type variable in `t` is not bound in
+// `env`, or if the type cannot be constructed.
+func Subst(t reflect.Type, env map[reflect.Type]reflect.Type) reflect.Type {
+	return substitution{tyenv(env), t, "type"}.tysubst(t)
+}
@@ -0,0 +1,75 @@
+package ty_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/BurntSushi/ty"
+)
+
+var (
+	tyA = reflect.TypeOf(ty.A{})
+	tyB = reflect.TypeOf(ty.B{})
+)
+
+func TestInstantiate(t *testing.T) {
+	bindings := map[reflect.Type]reflect.Type{
+		tyA: reflect.TypeOf(""),
+		tyB: reflect.TypeOf(0),
+	}
+	tests := []struct {
+		sig  interface{}
+		want reflect.Type
+	}{
+		{new([]ty.A), reflect.TypeOf([]string{})},
+		{
+			new(func(ty.A) (map[ty.A]ty.B, []ty.A)),
+			reflect.TypeOf(func(string) (map[string]int, []string) {
+				return nil, nil
+			}),
+		},
+		{new([2]*ty.B), reflect.TypeOf([2]*int{})},
+		{
+			new(struct{ First ty.A }),
+			reflect.TypeOf(struct{ First string }{}),
+		},
+		{new(error), reflect.TypeOf(new(error)).Elem()},
+		{reflect.TypeOf(ty.B{}), reflect.TypeOf(0)},
+	}
+	for _, test := range tests {
+		if got := ty.Instantiate(test.sig, bindings); got != test.want {
+			t.Errorf("Instantiate(%T): expected '%s' but got '%s'.",
+				test.sig, test.want, got)
+		}
+	}
+}
+
+func TestInstantiateErrors(t *testing.T) {
+	tests := []struct {
+		sig      interface{}
+		bindings map[reflect.Type]reflect.Type
+	}{
+		{new([]ty.A), nil},
+		{[]ty.A{}, map[reflect.Type]reflect.Type{tyA: tyB}},
+		{new([]ty.A), map[reflect.Type]reflect.Type{reflect.TypeOf(0): tyB}},
+	}
+	for _, test := range tests {
+		func() {
+			defer func() {
+				if _, ok := recover().(ty.TypeError); !ok {
+					t.Errorf("Instantiate(%T, %v) did not panic with a "+
+						"TypeError.", test.sig, test.bindings)
+				}
+			}()
+			ty.Instantiate(test.sig, test.bindings)
+		}()
+	}
+}
+
+func TestSubst(t *testing.T) {
+	env := map[reflect.Type]reflect.Type{tyA: reflect.TypeOf(0)}
+	got := ty.Subst(reflect.TypeOf(map[string]ty.A{}), env)
+	if want := reflect.TypeOf(map[string]int{}); got != want {
+		t.Fatalf("Expected '%s' but got '%s'.", want, got)
+	}
+}
@@ -0,0 +1,374 @@
+package ty
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Registry maps the names used in type expressions to Go types. Names of
+// types from other packages are qualified by the package name, e.g.,
+// `time.Duration`. Type variables may be registered too, which is how type
+// variables other than those defined in this package can be used.
+type Registry map[string]reflect.Type
+
+// builtinTypes are the names that can be used in type expressions without
+// being registered: Go's predeclared types and the type variables defined
+// in this package.
+var builtinTypes = Registry{
+	"bool":       reflect.TypeOf(false),
+	"int":        reflect.TypeOf(int(0)),
+	"int8":       reflect.TypeOf(int8(0)),
+	"int16":      reflect.TypeOf(int16(0)),
+	"int32":      reflect.TypeOf(int32(0)),
+	"int64":      reflect.TypeOf(int64(0)),
+	"uint":       reflect.TypeOf(uint(0)),
+	"uint8":      reflect.TypeOf(uint8(0)),
+	"uint16":     reflect.TypeOf(uint16(0)),
+	"uint32":     reflect.TypeOf(uint32(0)),
+	"uint64":     reflect.TypeOf(uint64(0)),
+	"uintptr":    reflect.TypeOf(uintptr(0)),
+	"float32":    reflect.TypeOf(float32(0)),
+	"float64":    reflect.TypeOf(float64(0)),
+	"complex64":  reflect.TypeOf(complex64(0)),
+	"complex128": reflect.TypeOf(complex128(0)),
+	"string":     reflect.TypeOf(""),
+	"byte":       reflect.TypeOf(byte(0)),
+	"rune":       reflect.TypeOf(rune(0)),
+	"error":      reflect.TypeOf(new(error)).Elem(),
+	"any":        reflect.TypeOf(new(interface{})).Elem(),
+
+	"A": reflect.TypeOf(A{}),
+	"B": reflect.TypeOf(B{}),
+	"C": reflect.TypeOf(C{}),
+	"D": reflect.TypeOf(D{}),
+	"E": reflect.TypeOf(E{}),
+	"F": reflect.TypeOf(F{}),
+	"G": reflect.TypeOf(G{}),
+}
+
+// ParseType returns the type written in Go syntax in `expr`, such as
+// `map[string][]A`. Slices, arrays, maps, channels (with directions),
+// pointers, functions, structs with exported fields and `interface{}` may be
+// used, along with named types. A name is looked up in `registry` first and
+// then among Go's predeclared types and the type variables `A` through `G`
+// defined in this package. `registry` may be nil.
+//
+// Since type variables are parsed like any other type, ParseType can build
+// the parametric types given to `Check`, e.g.,
+//
+//	sig, err := ParseType("func(func(A) B, []A) []B", nil)
+//	...
+//	chk := Check(reflect.New(sig).Interface(), f, xs)
+//
+// ParseType returns a `TypeError` if `expr` is not a type expression, uses
+// a name that is not known or is a type that cannot be constructed, such as
+// an array too large to fit in memory.
+func ParseType(
+	expr string,
+	registry Registry,
+) (typ reflect.Type, err error) {
+	x, err := parser.ParseExpr(expr)
+	if err != nil {
+		return nil, pe("Cannot parse type '%s': %s", expr, err)
+	}
+
+	// The `reflect` package panics with a string when it cannot construct a
+	// type, e.g., "reflect.ArrayOf: array size would exceed virtual address
+	// space".
+	defer func() {
+		if r := recover(); r != nil {
+			msg, ok := r.(string)
+			if !ok || !strings.HasPrefix(msg, "reflect.") {
+				panic(r)
+			}
+			typ, err = nil, pe("Cannot parse type '%s': %s", expr, msg)
+		}
+	}()
+	p := typeParser{expr, registry}
+	return p.typeOf(x)
+}
+
+// typeParser converts type expressions to Go types.
+type typeParser struct {
+	expr     string
+	registry Registry
+}
+
+func (p typeParser) error(format string, v ...interface{}) error {
+	return pe("Cannot parse type '%s': %s", p.expr, pe(format, v...))
+}
+
+func (p typeParser) typeOf(x ast.Expr) (reflect.Type, error) {
+	switch x := x.(type) {
+	case *ast.Ident:
+		return p.lookup(x.Name)
+	case *ast.SelectorExpr:
+		pkg, ok := x.X.(*ast.Ident)
+		if !ok {
+			return nil, p.error("Invalid qualified name.")
+		}
+		return p.lookup(pkg.Name + "." + x.Sel.Name)
+	case *ast.ParenExpr:
+		return p.typeOf(x.X)
+	case *ast.StarExpr:
+		elem, err := p.typeOf(x.X)
+		if err != nil {
+			return nil, err
+		}
+		return reflect.PtrTo(elem), nil
+	case *ast.ArrayType:
+		return p.arrayOf(x)
+	case *ast.MapType:
+		key, err := p.typeOf(x.Key)
+		if err != nil {
+			return nil, err
+		}
+		elem, err := p.typeOf(x.Value)
+		if err != nil {
+			return nil, err
+		}
+		if !key.Comparable() {
+			return nil, p.error("Invalid map key type '%s'.", key)
+		}
+		return reflect.MapOf(key, elem), nil
+	case *ast.ChanType:
+		elem, err := p.typeOf(x.Value)
+		if err != nil {
+			return nil, err
+		}
+		dir := reflect.BothDir
+		switch x.Dir {
+		case ast.SEND:
+			dir = reflect.SendDir
+		case ast.RECV:
+			dir = reflect.RecvDir
+		}
+		return reflect.ChanOf(dir, elem), nil
+	case *ast.FuncType:
+		return p.funcOf(x)
+	case *ast.StructType:
+		return p.structOf(x)
+	case *ast.InterfaceType:
+		if len(x.Methods.List) > 0 {
+			return nil, p.error("Interfaces with methods must be registered.")
+		}
+		return builtinTypes["any"], nil
+	}
+	return nil, p.error("Not a type.")
+}
+
+func (p typeParser) lookup(name string) (reflect.Type, error) {
+	if t, ok := p.registry[name]; ok {
+		return t, nil
+	}
+	if t, ok := builtinTypes[name]; ok {
+		return t, nil
+	}
+	return nil, p.error("Unknown type '%s'.", name)
+}
+
+func (p typeParser) arrayOf(x *ast.ArrayType) (reflect.Type, error) {
+	elem, err := p.typeOf(x.Elt)
+	if err != nil {
+		return nil, err
+	}
+	if x.Len == nil {
+		return reflect.SliceOf(elem), nil
+	}
+
+	lit, ok := x.Len.(*ast.BasicLit)
+	if !ok || lit.Kind != token.INT {
+		return nil, p.error("Array length must be an integer.")
+	}
+	n, err := strconv.ParseInt(lit.Value, 0, 0)
+	if err != nil || n < 0 {
+		return nil, p.error("Invalid array length '%s'.", lit.Value)
+	}
+	return reflect.ArrayOf(int(n), elem), nil
+}
+
+func (p typeParser) funcOf(x *ast.FuncType) (reflect.Type, error) {
+	ins, variadic, err := p.fieldTypes(x.Params)
+	if err != nil {
+		return nil, err
+	}
+	outs, _, err := p.fieldTypes(x.Results)
+	if err != nil {
+		return nil, err
+	}
+	return reflect.FuncOf(ins, outs, variadic), nil
+}
+
+// fieldTypes returns the types of the parameters or results in `fields`,
+// which may be nil, and whether the last parameter is variadic.
+func (p typeParser) fieldTypes(
+	fields *ast.FieldList,
+) ([]reflect.Type, bool, error) {
+	if fields == nil {
+		return nil, false, nil
+	}
+
+	var types []reflect.Type
+	variadic := false
+	for i, field := range fields.List {
+		x := field.Type
+		if ellipsis, ok := x.(*ast.Ellipsis); ok {
+			if i != len(fields.List)-1 || len(field.Names) > 1 {
+				return nil, false, p.error("Only the last parameter may be " +
+					"variadic.")
+			}
+			x, variadic = &ast.ArrayType{Elt: ellipsis.Elt}, true
+		}
+		t, err := p.typeOf(x)
+		if err != nil {
+			return nil, false, err
+		}
+
+		n := len(field.Names)
+		if n == 0 {
+			n = 1
+		}
+		for j := 0; j < n; j++ {
+			types = append(types, t)
+		}
+	}
+	return types, variadic, nil
+}
+
+func (p typeParser) structOf(x *ast.StructType) (reflect.Type, error) {
+	var fields []reflect.StructField
+	names := make(map[string]bool)
+	for _, field := range x.Fields.List {
+		t, err := p.typeOf(field.Type)
+		if err != nil {
+			return nil, err
+		}
+		if len(field.Names) == 0 {
+			return nil, p.error("Embedded fields are not supported.")
+		}
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				return nil, p.error("Field '%s' is not exported.", name.Name)
+			}
+			if names[name.Name] {
+				return nil, p.error("Duplicate field '%s'.", name.Name)
+			}
+			names[name.Name] = true
+			fields = append(fields, reflect.StructField{
+				Name: name.Name,
+				Type: t,
+			})
+		}
+	}
+	return reflect.StructOf(fields), nil
+}
+
+// FormatType returns `t` in Go syntax, with the type variables defined in
+// this package written without their package name, e.g., `map[string][]A`.
+// The result can be parsed by ParseType with a registry of the named types
+// in `t` from other packages, which are qualified by their package name.
+func FormatType(t reflect.Type) string {
+	var buf strings.Builder
+	formatType(&buf, t)
+	return buf.String()
+}
+
+func formatType(buf *strings.Builder, t reflect.Type) {
+	if t.Name() != "" {
+		if t.PkgPath() == tyvarUnderlyingType.PkgPath() && isTyvar(t) {
+			buf.WriteString(t.Name())
+		} else {
+			buf.WriteString(t.String())
+		}
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Array:
+		buf.WriteString("[" + strconv.Itoa(t.Len()) + "]")
+		formatType(buf, t.Elem())
+	case reflect.Chan:
+		switch t.ChanDir() {
+		case reflect.RecvDir:
+			buf.WriteString("<-chan ")
+		case reflect.SendDir:
+			buf.WriteString("chan<- ")
+		default:
+			buf.WriteString("chan ")
+		}
+		// `chan (<-chan int)` needs parentheses to not be `chan<- chan int`.
+		if t.ChanDir() == reflect.BothDir && t.Elem().Kind() == reflect.Chan &&
+			t.Elem().Name() == "" && t.Elem().ChanDir() == reflect.RecvDir {
+			buf.WriteString("(")
+			formatType(buf, t.Elem())
+			buf.WriteString(")")
+		} else {
+			formatType(buf, t.Elem())
+		}
+	case reflect.Func:
+		buf.WriteString("func")
+		formatSignature(buf, t)
+	case reflect.Map:
+		buf.WriteString("map[")
+		formatType(buf, t.Key())
+		buf.WriteString("]")
+		formatType(buf, t.Elem())
+	case reflect.Ptr:
+		buf.WriteString("*")
+		formatType(buf, t.Elem())
+	case reflect.Slice:
+		buf.WriteString("[]")
+		formatType(buf, t.Elem())
+	case reflect.Struct:
+		buf.WriteString("struct{")
+		for i := 0; i < t.NumField(); i++ {
+			if i > 0 {
+				buf.WriteString("; ")
+			}
+			buf.WriteString(t.Field(i).Name + " ")
+			formatType(buf, t.Field(i).Type)
+		}
+		buf.WriteString("}")
+	default:
+		// Unnamed interfaces. Only `interface{}` can be parsed again.
+		buf.WriteString(t.String())
+	}
+}
+
+// formatSignature writes the parameters and results of the function type
+// `t`, e.g., `(int, ...string) (bool, error)`.
+func formatSignature(buf *strings.Builder, t reflect.Type) {
+	buf.WriteString("(")
+	for i := 0; i < t.NumIn(); i++ {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		if t.IsVariadic() && i == t.NumIn()-1 {
+			buf.WriteString("...")
+			formatType(buf, t.In(i).Elem())
+		} else {
+			formatType(buf, t.In(i))
+		}
+	}
+	buf.WriteString(")")
+
+	switch t.NumOut() {
+	case 0:
+	case 1:
+		buf.WriteString(" ")
+		formatType(buf, t.Out(0))
+	default:
+		buf.WriteString(" (")
+		for i := 0; i < t.NumOut(); i++ {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			formatType(buf, t.Out(i))
+		}
+		buf.WriteString(")")
+	}
+}
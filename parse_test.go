@@ -0,0 +1,91 @@
+package ty_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/ty"
+)
+
+func TestParseType(t *testing.T) {
+	registry := ty.Registry{
+		"time.Duration": reflect.TypeOf(time.Duration(0)),
+		"ty_test.A":     reflect.TypeOf(A{}),
+	}
+	tests := []struct {
+		expr string
+		want interface{}
+	}{
+		{"int", new(int)},
+		{"map[string][]A", new(map[string][]ty.A)},
+		{"[3]*B", new([3]*ty.B)},
+		{"<-chan int", new(<-chan int)},
+		{"chan<- []string", new(chan<- []string)},
+		{"chan (<-chan int)", new(chan (<-chan int))},
+		{"func(func(A) B, []A) []B", new(func(func(ty.A) ty.B, []ty.A) []ty.B)},
+		{"func(a, b int, rest ...string) (bool, error)",
+			new(func(int, int, ...string) (bool, error))},
+		{"struct{First A; Second []B}",
+			new(struct {
+				First  ty.A
+				Second []ty.B
+			})},
+		{"map[time.Duration]interface{}", new(map[time.Duration]interface{})},
+		{"[]ty_test.A", new([]A)},
+	}
+	for _, test := range tests {
+		want := reflect.TypeOf(test.want).Elem()
+		got, err := ty.ParseType(test.expr, registry)
+		if err != nil {
+			t.Errorf("ParseType(%q): %s", test.expr, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParseType(%q): expected '%s' but got '%s'.",
+				test.expr, want, got)
+		}
+
+		// The formatted type parses to the same type.
+		again, err := ty.ParseType(ty.FormatType(got), registry)
+		if err != nil || again != got {
+			t.Errorf("ParseType(FormatType(%q)) = (%v, %v) (formatted as %q)",
+				test.expr, again, err, ty.FormatType(got))
+		}
+	}
+}
+
+func TestParseTypeErrors(t *testing.T) {
+	for _, expr := range []string{
+		"", "map[]int", "Unknown", "[]time.Duration", "map[[]int]bool",
+		"[n]int", "func(...int, string)", "struct{x int}", "1 + 2",
+		"[9223372036854775807]int", "[1099511627776][1099511627776]byte",
+		"struct{X int; X string}", "struct{X, X int}",
+	} {
+		if _, err := ty.ParseType(expr, nil); err == nil {
+			t.Errorf("ParseType(%q) did not return an error.", expr)
+		} else if _, ok := err.(ty.TypeError); !ok {
+			t.Errorf("ParseType(%q) returned a %T.", expr, err)
+		}
+	}
+}
+
+func TestFormatType(t *testing.T) {
+	tests := []struct {
+		typ  interface{}
+		want string
+	}{
+		{new(map[string][]ty.A), "map[string][]A"},
+		{new(func(func(ty.A) ty.B, []ty.A) []ty.B), "func(func(A) B, []A) []B"},
+		{new(func(...int) (int, error)), "func(...int) (int, error)"},
+		{new([]A), "[]ty_test.A"},
+		{new(<-chan time.Duration), "<-chan time.Duration"},
+	}
+	for _, test := range tests {
+		typ := reflect.TypeOf(test.typ).Elem()
+		if got := ty.FormatType(typ); got != test.want {
+			t.Errorf("FormatType(%s): expected %q but got %q.",
+				typ, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package ty
+
+import (
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Signature is the parametric type of a function, as given to `Check`,
+// along with the name of the function. For example,
+//
+//	sig := NewSignature("fun.Map", new(func(func(A) B, []A) []B))
+//
+// describes the `Map` function of the `fun` package, and its String method
+// returns `func fun.Map(func(A) B, []A) []B`.
+type Signature struct {
+	name string
+	typ  reflect.Type
+}
+
+// NewSignature returns the signature of the function `name` whose
+// parametric type is given by `f`, a pointer to a nil function like the `f`
+// given to `Check`. `name` is usually qualified by the package name.
+//
+// NewSignature panics with a `TypeError` if `f` is not a pointer to a
+// function.
+func NewSignature(name string, f interface{}) *Signature {
+	return &Signature{name, funcTypeOf("f", f)}
+}
+
+// Name returns the name of the function.
+func (s *Signature) Name() string {
+	return s.name
+}
+
+// Type returns the parametric function type.
+func (s *Signature) Type() reflect.Type {
+	return s.typ
+}
+
+// TypeVars returns the distinct type variables in the signature, in the
+// order in which they first appear.
+func (s *Signature) TypeVars() []reflect.Type {
+	var vars []reflect.Type
+	collectTyvars(s.typ, make(map[reflect.Type]bool), &vars)
+	return vars
+}
+
+// Params returns the parameter types. The last one is a slice if the
+// function is variadic.
+func (s *Signature) Params() []reflect.Type {
+	params := make([]reflect.Type, s.typ.NumIn())
+	for i := range params {
+		params[i] = s.typ.In(i)
+	}
+	return params
+}
+
+// Results returns the result types.
+func (s *Signature) Results() []reflect.Type {
+	results := make([]reflect.Type, s.typ.NumOut())
+	for i := range results {
+		results[i] = s.typ.Out(i)
+	}
+	return results
+}
+
+// String returns the signature in Go syntax, with type variables written as
+// by `FormatType`.
+func (s *Signature) String() string {
+	var buf strings.Builder
+	buf.WriteString("func ")
+	buf.WriteString(s.name)
+	formatSignature(&buf, s.typ)
+	return buf.String()
+}
+
+// collectTyvars appends the type variables in `t` that are not in `seen` to
+// `vars`. `seen` also holds the named types being visited.
+func collectTyvars(
+	t reflect.Type,
+	seen map[reflect.Type]bool,
+	vars *[]reflect.Type,
+) {
+	if seen[t] {
+		return
+	}
+	if isTyvar(t) {
+		seen[t] = true
+		*vars = append(*vars, t)
+		return
+	}
+	if t.Name() != "" {
+		seen[t] = true
+	}
+
+	switch t.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Ptr, reflect.Slice:
+		collectTyvars(t.Elem(), seen, vars)
+	case reflect.Func:
+		for i := 0; i < t.NumIn(); i++ {
+			collectTyvars(t.In(i), seen, vars)
+		}
+		for i := 0; i < t.NumOut(); i++ {
+			collectTyvars(t.Out(i), seen, vars)
+		}
+	case reflect.Map:
+		collectTyvars(t.Key(), seen, vars)
+		collectTyvars(t.Elem(), seen, vars)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			collectTyvars(t.Field(i).Type, seen, vars)
+		}
+	}
+}
+
+var signatures = struct {
+	sync.RWMutex
+	byName map[string]*Signature
+}{byName: make(map[string]*Signature)}
+
+// RegisterSignature makes the signature of the function `name`, whose
+// parametric type is given by `f`, available to `LookupSignature` and
+// `Signatures`, and returns it. Packages of parametric functions register
+// their signatures when they are initialized, so that tools can list them.
+//
+// If a function registered with a name qualified by its package name calls
+// `Check` with the same parametric type, the type errors reported by
+// `Check` name the function and print its signature.
+//
+// RegisterSignature panics with a `TypeError` if `f` is not a pointer to a
+// function or if a signature is already registered with the same name.
+func RegisterSignature(name string, f interface{}) *Signature {
+	sig := NewSignature(name, f)
+
+	signatures.Lock()
+	defer signatures.Unlock()
+	if _, ok := signatures.byName[name]; ok {
+		ppe("A signature is already registered for '%s'.", name)
+	}
+	signatures.byName[name] = sig
+	return sig
+}
+
+// LookupSignature returns the signature registered with the name `name`.
+func LookupSignature(name string) (*Signature, bool) {
+	signatures.RLock()
+	defer signatures.RUnlock()
+	sig, ok := signatures.byName[name]
+	return sig, ok
+}
+
+// Signatures returns all registered signatures, sorted by name.
+func Signatures() []*Signature {
+	signatures.RLock()
+	defer signatures.RUnlock()
+	sigs := make([]*Signature, 0, len(signatures.byName))
+	for _, sig := range signatures.byName {
+		sigs = append(sigs, sig)
+	}
+	sort.Slice(sigs, func(i, j int) bool {
+		return sigs[i].name < sigs[j].name
+	})
+	return sigs
+}
+
+// callerSignature returns the signature of the function that called
+// `Check` (or `Instance.Check`), if it is registered with the same parameter
+// types as the parametric type `tf`, or else `tf` itself. If `inst` is not
+// nil, the instance is included. It must be called directly by `check`.
+func callerSignature(inst *Instance, tf reflect.Type) string {
+	sig := signatureOf(callerName(3), tf)
+	if inst != nil {
+		sig += " of " + inst.String()
+	}
+	return sig
+}
+
+// callerName returns the name of a caller qualified by its package name,
+// e.g., `fun.Map`, where `skip` is the number of callers of callerName to
+// skip. It returns an empty string if there is no such caller.
+func callerName(skip int) string {
+	pcs := make([]uintptr, 1)
+	if runtime.Callers(skip+2, pcs) == 0 {
+		return ""
+	}
+	frame, _ := runtime.CallersFrames(pcs).Next()
+	return shortFuncName(frame.Function)
+}
+
+// shortFuncName returns the name of a function given by the `runtime`
+// package with its package path shortened to the package name.
+func shortFuncName(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+// signatureOf returns the signature registered with the name `name`, if it
+// has the same parameter types as the parametric type `tf`, or else `tf`
+// itself.
+func signatureOf(name string, tf reflect.Type) string {
+	if sig, ok := LookupSignature(name); ok && sameParams(sig.typ, tf) {
+		return sig.String()
+	}
+	return tf.String()
+}
+
+// sameParams returns true if the function types `t1` and `t2` have the same
+// parameter types.
+func sameParams(t1, t2 reflect.Type) bool {
+	if t1.NumIn() != t2.NumIn() || t1.IsVariadic() != t2.IsVariadic() {
+		return false
+	}
+	for i := 0; i < t1.NumIn(); i++ {
+		if t1.In(i) != t2.In(i) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+package ty_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/ty"
+)
+
+func TestSignature(t *testing.T) {
+	sig := ty.NewSignature("fun.Map",
+		new(func(func(ty.B) ty.A, []ty.B) (ty.C, []ty.A)))
+	tyC := reflect.TypeOf(ty.C{})
+
+	tests := []struct {
+		got, want interface{}
+	}{
+		{sig.Name(), "fun.Map"},
+		{sig.TypeVars(), []reflect.Type{tyB, tyA, tyC}},
+		{sig.Params(), []reflect.Type{
+			reflect.FuncOf([]reflect.Type{tyB}, []reflect.Type{tyA}, false),
+			reflect.SliceOf(tyB),
+		}},
+		{sig.Results(), []reflect.Type{tyC, reflect.SliceOf(tyA)}},
+		{sig.String(), "func fun.Map(func(B) A, []B) (C, []A)"},
+	}
+	for _, test := range tests {
+		if !reflect.DeepEqual(test.got, test.want) {
+			t.Errorf("Expected %v but got %v.", test.want, test.got)
+		}
+	}
+
+	variadic := ty.NewSignature("fmt.Sprintf",
+		new(func(string, ...interface{}) string))
+	if vars := variadic.TypeVars(); len(vars) != 0 {
+		t.Errorf("Expected no type variables but got %v.", vars)
+	}
+	want := "func fmt.Sprintf(string, ...interface {}) string"
+	if got := variadic.String(); got != want {
+		t.Errorf("Expected %q but got %q.", want, got)
+	}
+}
+
+// first has a registered parametric type, which type errors print.
+func first(xs interface{}) interface{} {
+	chk := ty.Check(new(func([]ty.A) ty.A), xs)
+	return reflect.Zero(chk.Returns[0]).Interface()
+}
+
+var firstSig = ty.RegisterSignature("ty_test.first", new(func([]ty.A) ty.A))
+
+func TestRegisterSignature(t *testing.T) {
+	if sig, ok := ty.LookupSignature("ty_test.first"); !ok || sig != firstSig {
+		t.Fatalf("LookupSignature returned (%v, %v).", sig, ok)
+	}
+	if _, ok := ty.LookupSignature("ty_test.unknown"); ok {
+		t.Fatalf("LookupSignature found an unregistered signature.")
+	}
+
+	found := false
+	sigs := ty.Signatures()
+	for i := range sigs {
+		if i > 0 && sigs[i-1].Name() >= sigs[i].Name() {
+			t.Fatalf("Signatures are not sorted by name.")
+		}
+		found = found || sigs[i] == firstSig
+	}
+	if !found {
+		t.Fatalf("Signatures does not include '%s'.", firstSig)
+	}
+
+	assertTypeError(t, "already registered", func() {
+		ty.RegisterSignature("ty_test.first", new(func()))
+	})
+	assertTypeError(t, "pointer to a function", func() {
+		ty.RegisterSignature("ty_test.second", func() {})
+	})
+}
+
+func TestCheckRegisteredSignature(t *testing.T) {
+	assertTypeError(t, "\n\tfunc ty_test.first([]A) A\n", func() {
+		first(5)
+	})
+}
+
+func assertTypeError(t *testing.T, substr string, f func()) {
+	t.Helper()
+	defer func() {
+		err, ok := recover().(ty.TypeError)
+		if !ok {
+			t.Fatalf("Expected a TypeError containing %q.", substr)
+		}
+		if !strings.Contains(err.Error(), substr) {
+			t.Fatalf("Expected a TypeError containing %q but got:\n%s",
+				substr, err)
+		}
+	}()
+	f()
+}
@@ -0,0 +1,162 @@
+package ty
+
+import (
+	"reflect"
+)
+
+// Specialize returns the parametric function `impl` as a function with the
+// concrete type given by `target`, so that callers can call it without type
+// assertions. `sig` is the parametric type of `impl` and `target` is a
+// concrete instance of it, both given as pointers to nil functions like the
+// `f` given to `Check`. For example, with the `Map` function described in
+// the documentation of `Check`:
+//
+//	mapItoa := Specialize(
+//		new(func(func(ty.A) ty.B, []ty.A) []ty.B),
+//		Map,
+//		new(func(func(int) string, []int) []string),
+//	).(func(func(int) string, []int) []string)
+//
+//	strs := mapItoa(strconv.Itoa, []int{1, 2, 3})
+//
+// The parameter types of `target` are unified with the parametric parameter
+// types of `sig` and the results of `sig` are instantiated, just as `Check`
+// would do with arguments of those types. This happens once, when
+// Specialize is called, and the result is remembered, so that `impl` is not
+// unified again when it checks `sig` with arguments of those types on each
+// call. (As with any call of `Check`, an `interface{}` parameter of
+// `target` is checked with the dynamic type of its argument instead.)
+//
+// Each parameter of `impl` must accept the corresponding parameter of
+// `target` (usually, it is an `interface{}`), and each result of `impl`
+// must either be assignable to the corresponding result of `target` or be
+// an interface whose dynamic value is. A nil interface result becomes the
+// zero value.
+//
+// Specialize panics with a `TypeError` if `target` is not an instance of
+// `sig` or if `impl` cannot be called with the types of `target`. If `impl`
+// returns a value that does not match `target` when called, the specialized
+// function panics with a `TypeError`.
+func Specialize(sig, impl, target interface{}) interface{} {
+	tsig := funcTypeOf("sig", sig)
+	ttarget := funcTypeOf("target", target)
+	vimpl := reflect.ValueOf(impl)
+	if vimpl.Kind() != reflect.Func || vimpl.IsNil() {
+		ppe("The implementation must be a function, but it is a '%T'.", impl)
+	}
+	timpl := vimpl.Type()
+
+	if tsig.NumIn() != ttarget.NumIn() || tsig.NumOut() != ttarget.NumOut() ||
+		tsig.IsVariadic() != ttarget.IsVariadic() {
+		ppe("Cannot specialize '%s' to '%s'.", tsig, ttarget)
+	}
+	env := make(tyenv)
+	for i := 0; i < tsig.NumIn(); i++ {
+		tp := typePair{
+			tyenv: env,
+			param: tsig.In(i),
+			input: ttarget.In(i),
+		}
+		param := position(nil).at("parameter", i+1)
+		if err := tp.unify(tp.param, tp.input, param); err != nil {
+			ppe("\nError specializing\n\t%s\nto\n\t%s\n%s", tsig, ttarget, err)
+		}
+	}
+	for i := 0; i < tsig.NumOut(); i++ {
+		sub := substitution{env, tsig.Out(i), "return type"}
+		if got, want := sub.tysubst(tsig.Out(i)), ttarget.Out(i); got != want {
+			ppe("Cannot specialize '%s' to '%s': result %d would have "+
+				"type '%s' but has type '%s'.", tsig, ttarget, i+1, got, want)
+		}
+	}
+
+	if timpl.NumIn() != ttarget.NumIn() ||
+		timpl.NumOut() != ttarget.NumOut() ||
+		timpl.IsVariadic() != ttarget.IsVariadic() {
+		ppe("Implementation of type '%s' cannot be called as '%s'.",
+			timpl, ttarget)
+	}
+	for i := 0; i < ttarget.NumIn(); i++ {
+		if !ttarget.In(i).AssignableTo(timpl.In(i)) {
+			ppe("Implementation of type '%s' cannot accept parameter %d "+
+				"of type '%s'.", timpl, i+1, ttarget.In(i))
+		}
+	}
+	for i := 0; i < ttarget.NumOut(); i++ {
+		tout := timpl.Out(i)
+		if !tout.AssignableTo(ttarget.Out(i)) &&
+			tout.Kind() != reflect.Interface {
+			ppe("Implementation of type '%s' cannot return result %d "+
+				"of type '%s'.", timpl, i+1, ttarget.Out(i))
+		}
+	}
+
+	checkSpecialized(tsig, ttarget)
+
+	specialized := func(in []reflect.Value) []reflect.Value {
+		var results []reflect.Value
+		if timpl.IsVariadic() {
+			results = vimpl.CallSlice(in)
+		} else {
+			results = vimpl.Call(in)
+		}
+		for i, r := range results {
+			results[i] = assignResult(ttarget.Out(i), r)
+		}
+		return results
+	}
+	return reflect.MakeFunc(ttarget, specialized).Interface()
+}
+
+// checkSpecialized checks the parametric type `tsig` with arguments of the
+// parameter types of `ttarget` and caches the result, so that an
+// implementation that checks the same type is not unified again when the
+// specialized function is called. Parameters with interface types have
+// dynamic types that are not known until then.
+func checkSpecialized(tsig, ttarget reflect.Type) {
+	if tracing() {
+		return
+	}
+	args := make([]reflect.Value, ttarget.NumIn())
+	for i := range args {
+		if ttarget.In(i).Kind() == reflect.Interface {
+			return
+		}
+		args[i] = reflect.Zero(ttarget.In(i))
+	}
+	if key, ok := newCheckKey(tsig, args); ok {
+		if c, err := checkArgs(nil, tsig, args); err == nil {
+			cacheCheck(key, c)
+		}
+	}
+}
+
+// assignResult returns the result `r` of an implementation as a value of
+// type `t`. If `r` is an interface that `t` cannot hold, its dynamic value
+// is used instead.
+func assignResult(t reflect.Type, r reflect.Value) reflect.Value {
+	if !r.Type().AssignableTo(t) {
+		if r.IsNil() {
+			return reflect.Zero(t)
+		}
+		r = r.Elem()
+		if !r.Type().AssignableTo(t) {
+			ppe("Specialized function expected a result of type '%s' but "+
+				"got '%s'.", t, r.Type())
+		}
+	}
+	v := reflect.New(t).Elem()
+	v.Set(r)
+	return v
+}
+
+// funcTypeOf returns the function type that `f` points to, or panics with a
+// `TypeError` if `f` is not a pointer to a function.
+func funcTypeOf(name string, f interface{}) reflect.Type {
+	t := reflect.TypeOf(f)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Func {
+		ppe("`%s` must be a pointer to a function, but it is a '%v'.",
+			name, t)
+	}
+	return t.Elem()
+}
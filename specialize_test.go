@@ -0,0 +1,101 @@
+package ty_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/BurntSushi/ty"
+)
+
+// mapImpl is a parametric Map, as in the documentation of Check.
+func mapImpl(f, xs interface{}) interface{} {
+	chk := ty.Check(
+		new(func(func(ty.A) ty.B, []ty.A) []ty.B),
+		f, xs)
+	vf, vxs, tys := chk.Args[0], chk.Args[1], chk.Returns[0]
+
+	xsLen := vxs.Len()
+	vys := reflect.MakeSlice(tys, xsLen, xsLen)
+	for i := 0; i < xsLen; i++ {
+		vys.Index(i).Set(vf.Call([]reflect.Value{vxs.Index(i)})[0])
+	}
+	return vys.Interface()
+}
+
+func TestSpecialize(t *testing.T) {
+	square := ty.Specialize(
+		new(func(func(ty.A) ty.B, []ty.A) []ty.B),
+		mapImpl,
+		new(func(func(int) int, []int) []int),
+	).(func(func(int) int, []int) []int)
+
+	got := square(func(x int) int { return x * x }, []int{1, 2, 3})
+	if want := []int{1, 4, 9}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Expected %v but got %v.", want, got)
+	}
+}
+
+func TestSpecializeChecksOnce(t *testing.T) {
+	sig := new(func(func(ty.A) ty.D, []ty.A) []ty.D)
+	var checks []*ty.Typed
+	impl := func(f, xs interface{}) interface{} {
+		chk := ty.Check(sig, f, xs)
+		checks = append(checks, chk)
+		return reflect.Zero(chk.Returns[0]).Interface()
+	}
+	f := ty.Specialize(
+		sig, impl, new(func(func(int8) int8, []int8) []int8),
+	).(func(func(int8) int8, []int8) []int8)
+	f(nil, nil)
+
+	// The check done by Specialize is shared by each call, but a caller
+	// that modifies its results does not change the next call's.
+	checks[0].Returns[0] = reflect.TypeOf("")
+	for name := range checks[0].TypeEnv {
+		delete(checks[0].TypeEnv, name)
+	}
+	f(nil, []int8{1})
+	want := reflect.TypeOf([]int8{})
+	if got := checks[1].Returns[0]; got != want {
+		t.Fatalf("Expected return type '%s' but got '%s'.", want, got)
+	}
+	if got := len(checks[1].TypeEnv); got != 2 {
+		t.Fatalf("Expected 2 type variables but got %d.", got)
+	}
+}
+
+func TestSpecializeErrors(t *testing.T) {
+	sig := new(func(func(ty.A) ty.B, []ty.A) []ty.B)
+	tests := []struct {
+		impl, target interface{}
+	}{
+		// Result type is not an instance of the signature.
+		{mapImpl, new(func(func(int) int, []int) []string)},
+		// Parameter types do not unify.
+		{mapImpl, new(func(func(int) int, []string) []int)},
+		// Implementation cannot accept the parameters.
+		{func(f func(int) int, xs []string) interface{} { return nil },
+			new(func(func(int) int, []int) []int)},
+		// Target is not a pointer to a function.
+		{mapImpl, func(func(int) int, []int) []int { return nil }},
+	}
+	for i, test := range tests {
+		func() {
+			defer func() {
+				if _, ok := recover().(ty.TypeError); !ok {
+					t.Errorf("Test %d did not panic with a TypeError.", i)
+				}
+			}()
+			ty.Specialize(sig, test.impl, test.target)
+		}()
+	}
+
+	// Parameters and results are numbered from 1, as in `Check`.
+	assertTypeError(t, "result 1 would have type '[]int'", func() {
+		ty.Specialize(sig, mapImpl, new(func(func(int) int, []int) []string))
+	})
+	assertTypeError(t, "cannot accept parameter 2 of type '[]int'", func() {
+		impl := func(f func(int) int, xs []string) interface{} { return nil }
+		ty.Specialize(sig, impl, new(func(func(int) int, []int) []int))
+	})
+}
@@ -0,0 +1,119 @@
+package ty
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// tracer is where trace output is written, if anywhere. It holds a
+// `*traceWriter` so that checking whether tracing is enabled is cheap.
+var tracer atomic.Value
+
+type traceWriter struct {
+	sync.Mutex
+	w io.Writer
+}
+
+func init() {
+	if os.Getenv("TYTRACE") != "" {
+		SetTrace(os.Stderr)
+	} else {
+		SetTrace(nil)
+	}
+}
+
+// SetTrace makes `Check` write a trace of its work to `w`: each pair of
+// types it unifies, each type variable it binds and each type variable it
+// substitutes in the return types. This is useful for understanding why a
+// parametric function rejects its arguments. A nil `w` turns tracing off,
+// which is the default unless the `TYTRACE` environment variable is set, in
+// which case the trace is written to standard error.
+//
+// Each line is written with a single call to `w.Write`, so the lines of
+// concurrent calls to `Check` are interleaved but not garbled.
+func SetTrace(w io.Writer) {
+	tracer.Store(&traceWriter{w: w})
+}
+
+// tracing returns true if trace output is written anywhere.
+func tracing() bool {
+	return tracer.Load().(*traceWriter).w != nil
+}
+
+// tracef writes a line of trace output, if tracing is enabled.
+func tracef(format string, v ...interface{}) {
+	tw := tracer.Load().(*traceWriter)
+	if tw.w == nil {
+		return
+	}
+	tw.Lock()
+	defer tw.Unlock()
+	fmt.Fprintf(tw.w, "ty: "+format+"\n", v...)
+}
+
+// binding records how `Check` bound a type variable, for `Typed.Explain`.
+type binding struct {
+	tyvar, typ reflect.Type
+
+	// The argument whose type bound the type variable, and where in the
+	// argument it was found, e.g., "element of argument 2".
+	arg   reflect.Type
+	where string
+}
+
+// Explain returns a description of how `Check` bound each type variable,
+// one per line in the order they were bound, including the argument and the
+// position within it of the type each was bound to. For example, after
+// checking `Map` with a `func(int) string` and a `[]int`, it returns
+//
+//	A = int, from parameter 1 of argument 1 'func(int) string'
+//	B = string, from result 1 of argument 1 'func(int) string'
+//
+// The types given to untyped nil arguments are described as well. After
+// `Instance.Check`, the type variables bound by the instance come first,
+// ordered by name, e.g.,
+//
+//	A = string, from instance OrdMap<string, int>
+//
+// Since `Check` does not keep track of where each type variable was bound,
+// Explain unifies the types of the arguments again.
+func (t *Typed) Explain() string {
+	var buf strings.Builder
+	env := make(tyenv)
+	if t.inst != nil {
+		for _, tyvar := range t.inst.tyvars() {
+			env[tyvar] = t.inst.tyenv[tyvar]
+			fmt.Fprintf(&buf, "%s = %s, from instance %s\n",
+				FormatType(tyvar), env[tyvar], t.inst)
+		}
+	}
+
+	var bindings []binding
+	if t.sig != nil {
+		args := make([]reflect.Value, len(t.Args))
+		for i := range args {
+			if i >= len(t.nils) || !t.nils[i] {
+				args[i] = t.Args[i]
+			}
+		}
+		// The arguments were already checked, so this cannot fail.
+		unifyArgs(env, t.sig, args, &bindings)
+	}
+
+	for _, b := range bindings {
+		fmt.Fprintf(&buf, "%s = %s, from %s '%s'\n",
+			FormatType(b.tyvar), b.typ, b.where, b.arg)
+	}
+	for i, arg := range t.Args {
+		if i < len(t.nils) && t.nils[i] {
+			fmt.Fprintf(&buf, "argument %d is an untyped nil of type '%s'\n",
+				i+1, arg.Type())
+		}
+	}
+	return buf.String()
+}
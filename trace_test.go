@@ -0,0 +1,67 @@
+package ty_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/ty"
+)
+
+func TestSetTrace(t *testing.T) {
+	var buf bytes.Buffer
+	ty.SetTrace(&buf)
+	defer ty.SetTrace(nil)
+
+	ty.Check(new(func(map[ty.A][]func(ty.B) ty.C) []ty.C),
+		map[string][]func(int) bool{})
+	for _, want := range []string{
+		"ty: Check func(map[A][]func(B) C) []C with argument types " +
+			"(map[string][]func(int) bool)\n",
+		"ty: unify B with int (parameter 1 of element of value of " +
+			"argument 1)\n",
+		"ty: bind C = bool\n",
+		"ty: subst C => bool\n",
+		"ty: return type 1: []C => []bool\n",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected the trace to contain %q, but it is:\n%s",
+				want, buf.String())
+		}
+	}
+
+	ty.SetTrace(nil)
+	buf.Reset()
+	ty.Check(new(func(ty.A)), 5)
+	if buf.Len() > 0 {
+		t.Errorf("Expected no trace, but got:\n%s", buf.String())
+	}
+}
+
+func TestExplain(t *testing.T) {
+	chk := ty.Check(new(func(func(ty.A) ty.B, []ty.A) []ty.B),
+		func(x int) string { return "" }, nil)
+	want := "A = int, from parameter 1 of argument 1 'func(int) string'\n" +
+		"B = string, from result 1 of argument 1 'func(int) string'\n" +
+		"argument 2 is an untyped nil of type '[]int'\n"
+	if got := chk.Explain(); got != want {
+		t.Errorf("Expected explanation\n%s\nbut got\n%s", want, got)
+	}
+
+	// A deeper position, explained the same way when checked again.
+	want = "A = bool, from element of value of argument 1 " +
+		"'map[string][]bool'\n"
+	for i := 0; i < 2; i++ {
+		chk := ty.Check(new(func(map[string][]ty.A)), map[string][]bool{})
+		if got := chk.Explain(); got != want {
+			t.Errorf("Expected explanation\n%s\nbut got\n%s", want, got)
+		}
+	}
+
+	// A Typed that was not returned by Check has nothing to explain.
+	user := &ty.Typed{Args: []reflect.Value{reflect.ValueOf(1)}}
+	if got := user.Explain(); got != "" {
+		t.Errorf("Expected no explanation but got\n%s", got)
+	}
+}
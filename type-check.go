@@ -3,7 +3,9 @@ package ty
 import (
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 )
 
 // TypeError corresponds to any error reported by the `Check` function.
@@ -32,9 +34,39 @@ type Typed struct {
 	// In correspondence with the return types of `f` in `Check`.
 	Returns []reflect.Type
 
-	// The type environment generated via unification in `Check`.
-	// (Its usefulness in the public API is questionable.)
+	// The type environment generated via unification in `Check`, keyed by
+	// the package-qualified name of each type variable, e.g.,
+	// `github.com/BurntSushi/ty.A`. Two distinct type variables declared
+	// with the same name in the same package (say, in different functions)
+	// share a key here, so prefer `Lookup`.
 	TypeEnv map[string]reflect.Type
+
+	tyenv tyenv
+
+	// nils[i] is true if `Args[i]` was given as an untyped nil.
+	nils []bool
+
+	// The parametric type given to `Check` and, in debug builds, the name
+	// of the function that called it, for `VerifyReturns`.
+	sig    reflect.Type
+	caller string
+
+	// The instance given to `Instance.Check`, or nil, for `Explain`.
+	inst *Instance
+}
+
+// Lookup returns the Go type that the type variable `tyvar` was bound to by
+// `Check`. If `tyvar` is not a type variable or does not occur in the
+// parametric type given to `Check`, Lookup returns false.
+//
+// For example, after checking `Map` with a `func(int) string`,
+//
+//	chk.Lookup(reflect.TypeOf(ty.B{}))
+//
+// returns the type of `string`.
+func (t *Typed) Lookup(tyvar reflect.Type) (reflect.Type, bool) {
+	typ, ok := t.tyenv[tyvar]
+	return typ, ok
 }
 
 // Check accepts a function `f`, which may have a parametric type, along with a
@@ -119,16 +151,45 @@ type Typed struct {
 //
 // There are a few restrictions imposed on the parametric return types of
 // `f`: type variables may only be found in types that can be composed by the
-// `reflect` package. This *only* includes channels, maps, pointers and slices.
-// If a type variable is found in an array, function or struct, `Check` will
-// panic.
+// `reflect` package. This includes arrays, channels, functions, maps,
+// pointers, slices and structs whose fields are all exported. If a type
+// variable is found in an interface or a struct with unexported fields,
+// `Check` will panic.
 //
 // Also, type variables inside of structs are ignored in the types of the
 // arguments `as`. This restriction may be lifted in the future.
 //
 // To be clear: type variables *may* appear in arrays or functions in the types
 // of the arguments `as`.
+//
+// Nil arguments
+//
+// A typed nil, such as a nil slice or function, is checked like any other
+// argument. An untyped nil (i.e., a nil `interface{}`) has no type of its
+// own, so it is given the zero value of its parameter type, with the type
+// variables in it bound by the other arguments. For example, checking `Map`
+// with a `func(int) string` and a nil list gives a nil `[]int`. If a type
+// variable in the parameter type is not bound by another argument, or if
+// the parameter type cannot be nil, `Check` will panic.
+//
+// Debugging
+//
+// `SetTrace` (or the `TYTRACE` environment variable) makes `Check` log each
+// step of unification and substitution, and `Typed.Explain` describes
+// which argument bound each type variable. In programs built with the
+// `tydebug` build tag, `Typed.VerifyReturns` and `Checked` check that a
+// parametric function returns values of the types in `Returns`.
 func Check(f interface{}, as ...interface{}) *Typed {
+	return check(nil, f, as)
+}
+
+// check implements `Check` and `Instance.Check`, where the type variables
+// bound by `inst`, if it is not nil, are bound before unification. It must
+// be called directly by one of them.
+func check(inst *Instance, f interface{}, as []interface{}) *Typed {
+	if f == nil {
+		ppe("The type of `f` must be a function, but it is nil.")
+	}
 	rf := reflect.ValueOf(f)
 	tf := rf.Type()
 
@@ -144,38 +205,277 @@ func Check(f interface{}, as ...interface{}) *Typed {
 			tf.NumIn(), len(as))
 	}
 
-	// Populate the argument value list.
+	// Populate the argument value list. Untyped nil arguments are invalid
+	// values until their types are inferred below.
 	args := make([]reflect.Value, len(as))
+	nils := make([]bool, len(as))
 	for i := 0; i < len(as); i++ {
 		args[i] = reflect.ValueOf(as[i])
+		nils[i] = !args[i].IsValid()
+	}
+	if tracing() {
+		tracef("Check %s with argument types (%s)",
+			FormatType(tf), argTypes(args))
 	}
 
-	// Populate our type variable environment through unification.
-	tyenv := make(tyenv)
+	// The result of checking a function type that was specialized with
+	// arguments of the same types, or a method type of an instance that
+	// binds all of its type variables, is shared, so its return types and
+	// type environment are copied. A trace always repeats the work.
+	var c *checked
+	if !tracing() {
+		if inst != nil {
+			c = inst.checkBound(tf, args)
+		} else if key, ok := newCheckKey(tf, args); ok {
+			c = cachedCheck(key)
+		}
+	}
+	shared := c != nil
+	if !shared {
+		var err error
+		if c, err = checkArgs(inst, tf, args); err != nil {
+			ppe("\nError type checking\n\t%s\nwith argument types\n\t(%s)\n%s",
+				callerSignature(inst, tf), argTypes(args), err)
+		}
+	}
 	for i := 0; i < len(args); i++ {
-		tp := typePair{tyenv, tf.In(i), args[i].Type()}
+		if nils[i] {
+			args[i] = reflect.Zero(c.nilTypes[i])
+		}
+	}
+	returns, typeEnv := c.returns, c.typeEnv
+	if shared {
+		returns = append([]reflect.Type(nil), returns...)
+		typeEnv = make(map[string]reflect.Type, len(c.typeEnv))
+		for name, typ := range c.typeEnv {
+			typeEnv[name] = typ
+		}
+	}
+	chk := &Typed{
+		Args:     args,
+		Returns:  returns,
+		TypeEnv:  typeEnv,
+		tyenv:    c.tyenv,
+		nils:     nils,
+		sig:      tf,
+		inst:     inst,
+	}
+	if debug {
+		chk.caller = callerName(2)
+	}
+	return chk
+}
 
-		// Mutates the type variable environment.
-		if err := tp.unify(tp.param, tp.input); err != nil {
-			argTypes := make([]string, len(args))
-			for i := range args {
-				argTypes[i] = args[i].Type().String()
-			}
-			ppe("\nError type checking\n\t%s\nwith argument types\n\t(%s)\n%s",
-				tf, strings.Join(argTypes, ", "), err)
+// checked is the part of `Typed` that depends only on the parametric type
+// given to `Check` and the types of the arguments, which may be shared by
+// many calls.
+type checked struct {
+	returns []reflect.Type
+	typeEnv map[string]reflect.Type
+	tyenv   tyenv
+
+	// nilTypes[i] is the type of argument i if it is an untyped nil.
+	nilTypes []reflect.Type
+}
+
+// checkArgs unifies the types of `args` with the parameter types of `tf`,
+// starting with the type variables bound by `inst` if it is not nil, and
+// substitutes the result in the return types of `tf`. Untyped nil arguments
+// are invalid values.
+func checkArgs(
+	inst *Instance,
+	tf reflect.Type,
+	args []reflect.Value,
+) (*checked, error) {
+	// Populate our type variable environment through unification, starting
+	// with the type variables bound by the instance.
+	tyenv := make(tyenv)
+	if inst != nil {
+		for tyvar, typ := range inst.tyenv {
+			tyenv[tyvar] = typ
+		}
+		if tracing() {
+			tracef("with the bindings of instance %s", inst)
+		}
+	}
+	if err := unifyArgs(tyenv, tf, args, nil); err != nil {
+		tracef("%s", err)
+		return nil, err
+	}
+
+	// An untyped nil argument is the zero value of its parameter type, as
+	// long as the other arguments bind all of the type variables in it and
+	// it can be nil.
+	nilTypes := make([]reflect.Type, len(args))
+	for i := 0; i < len(args); i++ {
+		if args[i].IsValid() {
+			continue
+		}
+		tparam := tf.In(i)
+		if !tyenv.binds(tparam) {
+			return nil, pe("Cannot infer the type of untyped nil argument "+
+				"%d of type '%s' from the other arguments.", i+1, tparam)
 		}
+		typ := substitution{tyenv, tparam, "parameter type"}.tysubst(tparam)
+		if !nillable(typ) {
+			return nil, pe("Argument %d of type '%s' cannot be nil.",
+				i+1, typ)
+		}
+		nilTypes[i] = typ
+		tracef("untyped nil argument %d has type '%s'", i+1, typ)
 	}
 
 	// Now substitute those types into the return types of `f`.
 	retTypes := make([]reflect.Type, tf.NumOut())
 	for i := 0; i < tf.NumOut(); i++ {
-		retTypes[i] = (&returnType{tyenv, tf.Out(i)}).tysubst(tf.Out(i))
+		sub := substitution{tyenv, tf.Out(i), "return type"}
+		retTypes[i] = sub.tysubst(tf.Out(i))
+		if tracing() {
+			tracef("return type %d: %s => %s",
+				i+1, FormatType(tf.Out(i)), retTypes[i])
+		}
+	}
+	return &checked{
+		returns:  retTypes,
+		typeEnv:  tyenv.byName(),
+		tyenv:    tyenv,
+		nilTypes: nilTypes,
+	}, nil
+}
+
+// unifyArgs unifies the types of `args` with the parameter types of `tf`,
+// which mutates `env`, and records how each type variable is bound in
+// `bindings` if it is not nil. Untyped nil arguments are invalid values,
+// which are skipped.
+func unifyArgs(
+	env tyenv,
+	tf reflect.Type,
+	args []reflect.Value,
+	bindings *[]binding,
+) error {
+	var steps [8]step
+	for i := 0; i < len(args); i++ {
+		if !args[i].IsValid() {
+			continue
+		}
+		tp := typePair{
+			tyenv:    env,
+			param:    tf.In(i),
+			input:    args[i].Type(),
+			bindings: bindings,
+		}
+		arg := position(steps[:0]).at("argument", i+1)
+		if err := tp.unify(tp.param, tp.input, arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// maxCachedArgs is the largest number of arguments of a check that is
+// cached, and maxCachedChecks is the largest number of checks cached.
+const (
+	maxCachedArgs   = 4
+	maxCachedChecks = 1024
+)
+
+// checkKey is a parametric function type along with the types of the
+// arguments it was checked with, where an untyped nil has a nil type.
+type checkKey struct {
+	f    reflect.Type
+	args [maxCachedArgs]reflect.Type
+}
+
+// checks caches the results of checking the parametric types given to
+// `Specialize` with arguments of the parameter types of its target, so that
+// an implementation called by the specialized function is not unified
+// again.
+var checks = struct {
+	sync.RWMutex
+	m map[checkKey]*checked
+}{m: make(map[checkKey]*checked)}
+
+// newCheckKey returns the key of checking `tf` with `args`, or false if the
+// check cannot be cached.
+func newCheckKey(tf reflect.Type, args []reflect.Value) (checkKey, bool) {
+	key := checkKey{f: tf}
+	if len(args) > maxCachedArgs {
+		return key, false
+	}
+	for i := range args {
+		if args[i].IsValid() {
+			key.args[i] = args[i].Type()
+		}
+	}
+	return key, true
+}
+
+// cachedCheck returns the cached check for `key`, or nil if there is none.
+func cachedCheck(key checkKey) *checked {
+	checks.RLock()
+	defer checks.RUnlock()
+	return checks.m[key]
+}
+
+// cacheCheck caches the check `c` for `key`, unless the cache is full.
+func cacheCheck(key checkKey, c *checked) {
+	checks.Lock()
+	defer checks.Unlock()
+	if len(checks.m) < maxCachedChecks {
+		checks.m[key] = c
 	}
-	return &Typed{args, retTypes, map[string]reflect.Type(tyenv)}
 }
 
-// tyenv maps type variable names to their inferred Go type.
-type tyenv map[string]reflect.Type
+// tyenv maps type variables to their inferred Go type. Type variables are
+// keyed by their `reflect.Type` rather than their name, so that type
+// variables with the same name declared in different packages are distinct.
+type tyenv map[reflect.Type]reflect.Type
+
+// binds returns true if every type variable in `t` is bound.
+func (env tyenv) binds(t reflect.Type) bool {
+	if isTyvar(t) {
+		_, ok := env[t]
+		return ok
+	}
+	if t.Name() != "" && !hasTyvars(t, nil) {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Ptr, reflect.Slice:
+		return env.binds(t.Elem())
+	case reflect.Func:
+		for i := 0; i < t.NumIn(); i++ {
+			if !env.binds(t.In(i)) {
+				return false
+			}
+		}
+		for i := 0; i < t.NumOut(); i++ {
+			if !env.binds(t.Out(i)) {
+				return false
+			}
+		}
+	case reflect.Map:
+		return env.binds(t.Key()) && env.binds(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !env.binds(t.Field(i).Type) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// byName returns the type environment keyed by the package-qualified name of
+// each type variable.
+func (env tyenv) byName() map[string]reflect.Type {
+	named := make(map[string]reflect.Type, len(env))
+	for tyvar, typ := range env {
+		named[tyvarName(tyvar)] = typ
+	}
+	return named
+}
 
 // typePair represents a pair of types to be unified. They act as a way to
 // report sensible error messages from within the unification algorithm.
@@ -185,6 +485,42 @@ type typePair struct {
 	tyenv tyenv
 	param reflect.Type
 	input reflect.Type
+
+	// bindings records how each type variable is bound, if it is not nil.
+	bindings *[]binding
+}
+
+// position is where in an argument the types being unified are found, as
+// the steps into the argument, outermost first. It is only formatted for
+// tracing and `Typed.Explain`.
+type position []step
+
+// step is a step into a type, e.g., "element", or into its `n`th component,
+// e.g., "parameter 1".
+type step struct {
+	name string
+	n    int
+}
+
+// at returns the position of the step `name` into the type at `pos`. Like
+// `append`, it may reuse the array of `pos`.
+func (pos position) at(name string, n int) position {
+	return append(pos, step{name, n})
+}
+
+// String returns the position in English, e.g., "element of argument 2".
+func (pos position) String() string {
+	var buf strings.Builder
+	for i := len(pos) - 1; i >= 0; i-- {
+		buf.WriteString(pos[i].name)
+		if pos[i].n > 0 {
+			buf.WriteString(" " + strconv.Itoa(pos[i].n))
+		}
+		if i > 0 {
+			buf.WriteString(" of ")
+		}
+	}
+	return buf.String()
 }
 
 func (tp typePair) error(format string, v ...interface{}) error {
@@ -201,18 +537,30 @@ func (tp typePair) error(format string, v ...interface{}) error {
 // Any failure to unify the two types results in a panic.
 //
 // The end result of unification is a type environment: a set of substitutions
-// from type variable to a Go type.
-func (tp typePair) unify(param, input reflect.Type) error {
-	if tyname := tyvarName(input); len(tyname) > 0 {
+// from type variable to a Go type. `where` is the position of `input` in the
+// type pair's input.
+func (tp typePair) unify(param, input reflect.Type, where position) error {
+	if isTyvar(input) {
 		return tp.error("Type variables are not allowed in the types of " +
 			"arguments.")
 	}
-	if tyname := tyvarName(param); len(tyname) > 0 {
-		if cur, ok := tp.tyenv[tyname]; ok && cur != input {
+	if tracing() {
+		tracef("unify %s with %s (%s)",
+			FormatType(param), input, where.String())
+	}
+	if isTyvar(param) {
+		if cur, ok := tp.tyenv[param]; ok && cur != input {
 			return tp.error("Type variable %s expected type '%s' but got '%s'.",
-				tyname, cur, input)
+				tyvarName(param), cur, input)
 		} else if !ok {
-			tp.tyenv[tyname] = input
+			tp.tyenv[param] = input
+			if tracing() {
+				tracef("bind %s = %s", FormatType(param), input)
+			}
+			if tp.bindings != nil {
+				*tp.bindings = append(*tp.bindings,
+					binding{param, input, tp.input, where.String()})
+			}
 		}
 		return nil
 	}
@@ -223,37 +571,40 @@ func (tp typePair) unify(param, input reflect.Type) error {
 
 	switch param.Kind() {
 	case reflect.Array:
-		return tp.unify(param.Elem(), input.Elem())
+		return tp.unify(param.Elem(), input.Elem(), where.at("element", 0))
 	case reflect.Chan:
 		if param.ChanDir() != input.ChanDir() {
 			return tp.error("Cannot unify '%s' with '%s' "+
 				"(channel directions are different: '%s' != '%s').",
 				param, input, param.ChanDir(), input.ChanDir())
 		}
-		return tp.unify(param.Elem(), input.Elem())
+		return tp.unify(param.Elem(), input.Elem(), where.at("element", 0))
 	case reflect.Func:
 		if param.NumIn() != input.NumIn() || param.NumOut() != input.NumOut() {
 			return tp.error("Cannot unify '%s' with '%s'.", param, input)
 		}
 		for i := 0; i < param.NumIn(); i++ {
-			if err := tp.unify(param.In(i), input.In(i)); err != nil {
+			in := where.at("parameter", i+1)
+			if err := tp.unify(param.In(i), input.In(i), in); err != nil {
 				return err
 			}
 		}
 		for i := 0; i < param.NumOut(); i++ {
-			if err := tp.unify(param.Out(i), input.Out(i)); err != nil {
+			out := where.at("result", i+1)
+			if err := tp.unify(param.Out(i), input.Out(i), out); err != nil {
 				return err
 			}
 		}
 	case reflect.Map:
-		if err := tp.unify(param.Key(), input.Key()); err != nil {
+		key := where.at("key", 0)
+		if err := tp.unify(param.Key(), input.Key(), key); err != nil {
 			return err
 		}
-		return tp.unify(param.Elem(), input.Elem())
+		return tp.unify(param.Elem(), input.Elem(), where.at("value", 0))
 	case reflect.Ptr:
-		return tp.unify(param.Elem(), input.Elem())
+		return tp.unify(param.Elem(), input.Elem(), where.at("element", 0))
 	case reflect.Slice:
-		return tp.unify(param.Elem(), input.Elem())
+		return tp.unify(param.Elem(), input.Elem(), where.at("element", 0))
 	}
 
 	// The only other container types are Interface and Struct.
@@ -263,53 +614,80 @@ func (tp typePair) unify(param, input reflect.Type) error {
 	return nil
 }
 
-// returnType corresponds to the type of a single return value of a function,
-// in which the type may be parametric. It also contains a type environment
-// constructed from unification.
-type returnType struct {
+// substitution corresponds to a type in which the type may be parametric,
+// such as the type of a single return value of a function. It also contains
+// a type environment constructed from unification or given explicitly.
+type substitution struct {
 	tyenv tyenv
 	typ   reflect.Type
+
+	// What the type is, for error messages. e.g., "return type".
+	desc string
 }
 
-func (rt returnType) panic(format string, v ...interface{}) {
-	ppe("Error substituting in return type '%s': %s",
-		rt.typ, fmt.Sprintf(format, v...))
+func (sub substitution) panic(format string, v ...interface{}) {
+	ppe("Error substituting in %s '%s': %s",
+		sub.desc, sub.typ, fmt.Sprintf(format, v...))
 }
 
-// tysubst attempts to substitute all type variables within a single return
-// type with their corresponding Go type from the type environment.
+// tysubst attempts to substitute all type variables within a single type
+// with their corresponding Go type from the type environment. Types without
+// any type variables are returned unchanged.
 //
 // tysubst will panic if a type variable is unbound, or if it encounters a
-// type that cannot be dynamically created. Such types include arrays,
-// functions and structs. (A limitation of the `reflect` package.)
-func (rt returnType) tysubst(typ reflect.Type) reflect.Type {
-	if tyname := tyvarName(typ); len(tyname) > 0 {
-		if thetype, ok := rt.tyenv[tyname]; !ok {
-			rt.panic("Unbound type variable %s.", tyname)
+// type containing type variables that cannot be dynamically created. Such
+// types include interfaces and structs with unexported fields. (A limitation
+// of the `reflect` package.)
+func (sub substitution) tysubst(typ reflect.Type) reflect.Type {
+	if isTyvar(typ) {
+		if thetype, ok := sub.tyenv[typ]; !ok {
+			sub.panic("Unbound type variable %s.", tyvarName(typ))
 		} else {
+			if tracing() {
+				tracef("subst %s => %s", FormatType(typ), thetype)
+			}
 			return thetype
 		}
 	}
+	if !hasTyvars(typ, nil) {
+		return typ
+	}
 
 	switch typ.Kind() {
 	case reflect.Array:
-		rt.panic("Cannot dynamically create Array types.")
+		return reflect.ArrayOf(typ.Len(), sub.tysubst(typ.Elem()))
 	case reflect.Chan:
-		return reflect.ChanOf(typ.ChanDir(), rt.tysubst(typ.Elem()))
+		return reflect.ChanOf(typ.ChanDir(), sub.tysubst(typ.Elem()))
 	case reflect.Func:
-		rt.panic("Cannot dynamically create Function types.")
+		ins := make([]reflect.Type, typ.NumIn())
+		for i := range ins {
+			ins[i] = sub.tysubst(typ.In(i))
+		}
+		outs := make([]reflect.Type, typ.NumOut())
+		for i := range outs {
+			outs[i] = sub.tysubst(typ.Out(i))
+		}
+		return reflect.FuncOf(ins, outs, typ.IsVariadic())
 	case reflect.Interface:
-		rt.panic("TODO")
+		sub.panic("Cannot dynamically create Interface types.")
 	case reflect.Map:
-		return reflect.MapOf(rt.tysubst(typ.Key()), rt.tysubst(typ.Elem()))
+		return reflect.MapOf(sub.tysubst(typ.Key()), sub.tysubst(typ.Elem()))
 	case reflect.Ptr:
-		return reflect.PtrTo(rt.tysubst(typ.Elem()))
+		return reflect.PtrTo(sub.tysubst(typ.Elem()))
 	case reflect.Slice:
-		return reflect.SliceOf(rt.tysubst(typ.Elem()))
+		return reflect.SliceOf(sub.tysubst(typ.Elem()))
 	case reflect.Struct:
-		rt.panic("Cannot dynamically create Struct types.")
-	case reflect.UnsafePointer:
-		rt.panic("Cannot dynamically create unsafe.Pointer types.")
+		fields := make([]reflect.StructField, typ.NumField())
+		for i := range fields {
+			fields[i] = typ.Field(i)
+			if fields[i].PkgPath != "" {
+				sub.panic("Cannot dynamically create Struct types with "+
+					"unexported fields (found '%s').", fields[i].Name)
+			}
+			fields[i].Type = sub.tysubst(fields[i].Type)
+			fields[i].Index, fields[i].Offset = nil, 0
+		}
+		return reflect.StructOf(fields)
 	}
 
 	// We've covered all the composite types, so we're only left with
@@ -317,16 +695,77 @@ func (rt returnType) tysubst(typ reflect.Type) reflect.Type {
 	return typ
 }
 
+// hasTyvars returns true if `t` is or contains a type variable. `seen`
+// holds the named types being visited, which stops recursive types from
+// being visited forever. It may be nil.
+func hasTyvars(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if isTyvar(t) {
+		return true
+	}
+	if t.Name() != "" {
+		if seen[t] {
+			return false
+		}
+		if seen == nil {
+			seen = make(map[reflect.Type]bool)
+		}
+		seen[t] = true
+	}
+
+	switch t.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Ptr, reflect.Slice:
+		return hasTyvars(t.Elem(), seen)
+	case reflect.Func:
+		for i := 0; i < t.NumIn(); i++ {
+			if hasTyvars(t.In(i), seen) {
+				return true
+			}
+		}
+		for i := 0; i < t.NumOut(); i++ {
+			if hasTyvars(t.Out(i), seen) {
+				return true
+			}
+		}
+	case reflect.Map:
+		return hasTyvars(t.Key(), seen) || hasTyvars(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if hasTyvars(t.Field(i).Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isTyvar returns true if `t` is a type variable.
+func isTyvar(t reflect.Type) bool {
+	return t.ConvertibleTo(tyvarUnderlyingType)
+}
+
+// tyvarName returns the package-qualified name of the type variable `t`,
+// e.g., `github.com/BurntSushi/ty.A`.
 func tyvarName(t reflect.Type) string {
-	if !t.ConvertibleTo(tyvarUnderlyingType) {
-		return ""
+	if t.PkgPath() == "" {
+		return t.Name()
 	}
-	return t.Name()
+	return t.PkgPath() + "." + t.Name()
 }
 
 // AssertType panics with a `TypeError` if `v` does not have type `t`.
 // Otherwise, it returns the `reflect.Value` of `v`.
+//
+// If `v` is an untyped nil, AssertType returns the zero value of `t` when
+// `t` can be nil (e.g., a slice, map or function type), and panics with a
+// `TypeError` otherwise.
 func AssertType(v interface{}, t reflect.Type) reflect.Value {
+	if v == nil {
+		if !nillable(t) {
+			ppe("Value 'nil' is untyped but expected '%s', which cannot be "+
+				"nil.", t)
+		}
+		return reflect.Zero(t)
+	}
 	rv := reflect.ValueOf(v)
 	tv := rv.Type()
 	if tv != t {
@@ -334,3 +773,27 @@ func AssertType(v interface{}, t reflect.Type) reflect.Value {
 	}
 	return rv
 }
+
+// nillable returns true if `nil` is a value of type `t`.
+func nillable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map,
+		reflect.Ptr, reflect.Slice, reflect.UnsafePointer:
+		return true
+	}
+	return false
+}
+
+// argTypes returns the types of `args` for error messages, where an invalid
+// value is an untyped nil.
+func argTypes(args []reflect.Value) string {
+	types := make([]string, len(args))
+	for i := range args {
+		if args[i].IsValid() {
+			types[i] = args[i].Type().String()
+		} else {
+			types[i] = "nil"
+		}
+	}
+	return strings.Join(types, ", ")
+}
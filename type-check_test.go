@@ -0,0 +1,121 @@
+package ty_test
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/ty"
+)
+
+// A has the same name as `ty.A` but is a different type variable.
+type A ty.TypeVariable
+
+func TestCheckDistinctTyvars(t *testing.T) {
+	chk := ty.Check(
+		new(func(A, ty.A) (A, ty.A)),
+		1, "a")
+
+	if chk.Returns[0] != reflect.TypeOf(0) {
+		t.Fatalf("Expected return type 'int' but got '%s'.", chk.Returns[0])
+	}
+	if chk.Returns[1] != reflect.TypeOf("") {
+		t.Fatalf("Expected return type 'string' but got '%s'.",
+			chk.Returns[1])
+	}
+}
+
+func TestLookup(t *testing.T) {
+	chk := ty.Check(
+		new(func(func(ty.A) ty.B, []ty.A) []ty.B),
+		func(n int) string { return "" }, []int{})
+
+	if typ, ok := chk.Lookup(reflect.TypeOf(ty.B{})); !ok {
+		t.Fatal("Type variable ty.B is not bound.")
+	} else if typ != reflect.TypeOf("") {
+		t.Fatalf("Expected ty.B to be 'string' but got '%s'.", typ)
+	}
+	if _, ok := chk.Lookup(reflect.TypeOf(A{})); ok {
+		t.Fatal("Type variable A is bound but does not occur in the type.")
+	}
+
+	typ := chk.TypeEnv["github.com/BurntSushi/ty.A"]
+	if typ != reflect.TypeOf(0) {
+		t.Fatalf("Expected ty.A to be 'int' but got '%v'.", typ)
+	}
+}
+
+func TestCheckQualifiedError(t *testing.T) {
+	defer func() {
+		err, ok := recover().(ty.TypeError)
+		if !ok {
+			t.Fatal("Check did not panic with a TypeError.")
+		}
+		want := "Type variable github.com/BurntSushi/ty_test.A expected"
+		if !strings.Contains(string(err), want) {
+			t.Fatalf("Expected error to contain %q, but got: %s", want, err)
+		}
+	}()
+	ty.Check(new(func(A, A)), 1, "a")
+}
+
+func TestCheckUntypedNil(t *testing.T) {
+	chk := ty.Check(
+		new(func(func(ty.A) ty.B, []ty.A) []ty.B),
+		strconv.Itoa, nil)
+	if got, want := chk.Args[1].Type(), reflect.TypeOf([]int{}); got != want {
+		t.Fatalf("Expected nil argument of type '%s' but got '%s'.", want, got)
+	}
+	if !chk.Args[1].IsNil() {
+		t.Fatal("Expected nil argument to be nil.")
+	}
+
+	chk = ty.Check(new(func(map[string]int, error)), nil, nil)
+	if !chk.Args[0].IsNil() || chk.Args[1].Kind() != reflect.Interface {
+		t.Fatalf("Expected nil map and error, but got %v.", chk.Args)
+	}
+}
+
+func TestCheckUntypedNilErrors(t *testing.T) {
+	tests := []struct {
+		f  interface{}
+		as []interface{}
+	}{
+		// ty.B is not bound by any other argument.
+		{new(func(func(ty.A) ty.B, []ty.A)), []interface{}{nil, []int{}}},
+		// ty.A is bound to a type that cannot be nil.
+		{new(func(ty.A, ty.A)), []interface{}{1, nil}},
+		// int cannot be nil.
+		{new(func(int)), []interface{}{nil}},
+		// f itself is nil.
+		{nil, nil},
+	}
+	for i, test := range tests {
+		func() {
+			defer func() {
+				if _, ok := recover().(ty.TypeError); !ok {
+					t.Errorf("Test %d did not panic with a TypeError.", i)
+				}
+			}()
+			ty.Check(test.f, test.as...)
+		}()
+	}
+}
+
+func TestAssertTypeNil(t *testing.T) {
+	tslice := reflect.TypeOf([]int{})
+	if v := ty.AssertType(nil, tslice); v.Type() != tslice || !v.IsNil() {
+		t.Fatalf("Expected a nil '%s' but got %v.", tslice, v)
+	}
+	if v := ty.AssertType([]int(nil), tslice); !v.IsNil() {
+		t.Fatalf("Expected a nil '%s' but got %v.", tslice, v)
+	}
+
+	defer func() {
+		if _, ok := recover().(ty.TypeError); !ok {
+			t.Fatal("AssertType(nil, int) did not panic with a TypeError.")
+		}
+	}()
+	ty.AssertType(nil, reflect.TypeOf(0))
+}
@@ -0,0 +1,281 @@
+package tyvet
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+)
+
+// tyPkgPath is the import path of the package defining type variables.
+const tyPkgPath = "github.com/BurntSushi/ty"
+
+// node is a parametric type, as written in the `new(func(...))` given to
+// `ty.Check`. It is a plain tree rather than a `types.Type` so that it can
+// be exported as a fact and used in packages that cannot see the type
+// variables it refers to.
+//
+// Like `ty.Check` at run time, unification only looks at the kinds of
+// types, except for type variables and the components of arrays, channels,
+// functions, maps, pointers and slices.
+type node struct {
+	// Kind is the name of the `reflect.Kind` of the type, or "tyvar" for a
+	// type variable.
+	Kind string
+
+	// Name is the package-qualified name of a type variable, or the string
+	// form of any other type.
+	Name string
+
+	// Basic is the `types.BasicKind` of an unnamed basic type, and zero
+	// otherwise.
+	Basic types.BasicKind
+
+	// Named is true for named types other than type variables, which
+	// cannot be constructed without their package.
+	Named bool
+
+	Len      int64
+	Dir      types.ChanDir
+	Variadic bool
+
+	// Elems holds the element type of arrays, channels, pointers and
+	// slices, the key and element types of maps, and the parameter types
+	// followed by the result types of functions.
+	Elems []node
+	NumIn int
+
+	// EmptyInterface is true for `interface{}`.
+	EmptyInterface bool
+}
+
+func (n node) String() string {
+	return n.Name
+}
+
+// nodeOf returns the parametric type `t` as a node.
+func nodeOf(t types.Type) node {
+	n := node{Kind: kindOf(t), Name: typeString(t)}
+	if isTyvar(t) {
+		obj := types.Unalias(t).(*types.Named).Obj()
+		n.Kind, n.Name = "tyvar", obj.Pkg().Path()+"."+obj.Name()
+		return n
+	}
+	switch t := types.Unalias(t).(type) {
+	case *types.Basic:
+		n.Basic = t.Kind()
+	case *types.Named:
+		n.Named = true
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Array:
+		n.Len = u.Len()
+		n.Elems = []node{nodeOf(u.Elem())}
+	case *types.Chan:
+		n.Dir = u.Dir()
+		n.Elems = []node{nodeOf(u.Elem())}
+	case *types.Pointer:
+		n.Elems = []node{nodeOf(u.Elem())}
+	case *types.Slice:
+		n.Elems = []node{nodeOf(u.Elem())}
+	case *types.Map:
+		n.Elems = []node{nodeOf(u.Key()), nodeOf(u.Elem())}
+	case *types.Signature:
+		n.Variadic = u.Variadic()
+		n.NumIn = u.Params().Len()
+		for i := 0; i < u.Params().Len(); i++ {
+			n.Elems = append(n.Elems, nodeOf(u.Params().At(i).Type()))
+		}
+		for i := 0; i < u.Results().Len(); i++ {
+			n.Elems = append(n.Elems, nodeOf(u.Results().At(i).Type()))
+		}
+	case *types.Interface:
+		n.EmptyInterface = u.Empty() && types.Unalias(t) == u
+	}
+	return n
+}
+
+// env maps the package-qualified names of type variables to the types they
+// are bound to.
+type env map[string]types.Type
+
+// unify binds the type variables in `param` to the types in `input` in the
+// same way as `ty.Check`, and returns an error describing the first
+// mismatch.
+func (e env) unify(param node, input types.Type) error {
+	if param.Kind == "tyvar" {
+		if cur, ok := e[param.Name]; ok && !types.Identical(cur, input) {
+			return fmt.Errorf("type variable %s is bound to %s, but got %s",
+				shortName(param.Name), typeString(cur), typeString(input))
+		}
+		e[param.Name] = input
+		return nil
+	}
+	if kind := kindOf(input); param.Kind != kind {
+		return fmt.Errorf("cannot use %s as %s", typeString(input), param)
+	}
+
+	switch u := input.Underlying().(type) {
+	case *types.Array:
+		return e.unify(param.Elems[0], u.Elem())
+	case *types.Chan:
+		if param.Dir != u.Dir() {
+			return fmt.Errorf("cannot use %s as %s (channel directions "+
+				"are different)", typeString(input), param)
+		}
+		return e.unify(param.Elems[0], u.Elem())
+	case *types.Pointer:
+		return e.unify(param.Elems[0], u.Elem())
+	case *types.Slice:
+		return e.unify(param.Elems[0], u.Elem())
+	case *types.Map:
+		if err := e.unify(param.Elems[0], u.Key()); err != nil {
+			return err
+		}
+		return e.unify(param.Elems[1], u.Elem())
+	case *types.Signature:
+		ps, rs := u.Params(), u.Results()
+		if param.NumIn != ps.Len() || len(param.Elems)-param.NumIn != rs.Len() {
+			return fmt.Errorf("cannot use %s as %s", typeString(input), param)
+		}
+		for i := 0; i < ps.Len(); i++ {
+			if err := e.unify(param.Elems[i], ps.At(i).Type()); err != nil {
+				return err
+			}
+		}
+		for i := 0; i < rs.Len(); i++ {
+			err := e.unify(param.Elems[param.NumIn+i], rs.At(i).Type())
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// subst returns the type `n` with its type variables replaced by the types
+// they are bound to, or nil if a type variable is unbound or the type
+// cannot be constructed from `n` alone (e.g., it is a named type).
+func (e env) subst(n node) types.Type {
+	elems := make([]types.Type, len(n.Elems))
+	for i := range n.Elems {
+		if elems[i] = e.subst(n.Elems[i]); elems[i] == nil {
+			return nil
+		}
+	}
+
+	switch {
+	case n.Kind == "tyvar":
+		return e[n.Name]
+	case n.Basic != types.Invalid:
+		return types.Typ[n.Basic]
+	case n.EmptyInterface:
+		return types.NewInterfaceType(nil, nil)
+	case n.Named:
+		return nil
+	}
+
+	switch n.Kind {
+	case "Array":
+		return types.NewArray(elems[0], n.Len)
+	case "Chan":
+		return types.NewChan(n.Dir, elems[0])
+	case "Ptr":
+		return types.NewPointer(elems[0])
+	case "Slice":
+		return types.NewSlice(elems[0])
+	case "Map":
+		return types.NewMap(elems[0], elems[1])
+	case "Func":
+		return types.NewSignatureType(nil, nil, nil,
+			tuple(elems[:n.NumIn]), tuple(elems[n.NumIn:]), n.Variadic)
+	}
+	return nil
+}
+
+func tuple(ts []types.Type) *types.Tuple {
+	vars := make([]*types.Var, len(ts))
+	for i, t := range ts {
+		vars[i] = types.NewParam(0, nil, "", t)
+	}
+	return types.NewTuple(vars...)
+}
+
+// isTyvar returns true if `t` is a type variable, i.e., a named type whose
+// underlying type is that of `ty.TypeVariable`.
+func isTyvar(t types.Type) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return false
+	}
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok || st.NumFields() != 1 {
+		return false
+	}
+	f := st.Field(0)
+	return f.Name() == "noImitation" && f.Pkg() != nil &&
+		f.Pkg().Path() == tyPkgPath
+}
+
+// kindOf returns the name of the `reflect.Kind` of `t`. Untyped constants
+// have the kind of their default type.
+func kindOf(t types.Type) string {
+	switch u := types.Default(t).Underlying().(type) {
+	case *types.Basic:
+		return basicKinds[u.Kind()]
+	case *types.Array:
+		return "Array"
+	case *types.Chan:
+		return "Chan"
+	case *types.Signature:
+		return "Func"
+	case *types.Interface:
+		return "Interface"
+	case *types.Map:
+		return "Map"
+	case *types.Pointer:
+		return "Ptr"
+	case *types.Slice:
+		return "Slice"
+	case *types.Struct:
+		return "Struct"
+	}
+	return "Invalid"
+}
+
+var basicKinds = map[types.BasicKind]string{
+	types.Bool:          "Bool",
+	types.Int:           "Int",
+	types.Int8:          "Int8",
+	types.Int16:         "Int16",
+	types.Int32:         "Int32",
+	types.Int64:         "Int64",
+	types.Uint:          "Uint",
+	types.Uint8:         "Uint8",
+	types.Uint16:        "Uint16",
+	types.Uint32:        "Uint32",
+	types.Uint64:        "Uint64",
+	types.Uintptr:       "Uintptr",
+	types.Float32:       "Float32",
+	types.Float64:       "Float64",
+	types.Complex64:     "Complex64",
+	types.Complex128:    "Complex128",
+	types.String:        "String",
+	types.UnsafePointer: "UnsafePointer",
+}
+
+// typeString returns `t` with package-qualified names, e.g., `[]ty.A`.
+func typeString(t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		return p.Name()
+	})
+}
+
+// shortName returns the package-qualified name of a type variable with its
+// package path shortened to the package name, e.g., `ty.A`.
+func shortName(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
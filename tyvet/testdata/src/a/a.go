@@ -0,0 +1,41 @@
+package a
+
+import (
+	"strconv"
+
+	"lib"
+)
+
+type ints []int
+
+func calls() {
+	_ = lib.Map(strconv.Itoa, []int{1, 2}).([]string)
+	_ = lib.Map(strconv.Itoa, ints{1, 2}).([]string)
+	_ = lib.Map(strconv.Itoa, []string{"a"}) // want `lib.Map: argument 2 of type \[\]string does not match parametric type func\(func\(ty.A\) ty.B, \[\]ty.A\) \[\]ty.B: type variable ty.A is bound to int, but got string`
+	_ = lib.Map(strconv.Itoa, []int{1}).([]int) // want `lib.Map returns \[\]string here, but its result is asserted to \[\]int`
+	_ = lib.Map(5, []int{1}) // want `argument 1 of type int does not match parametric type .*: cannot use int as func\(ty.A\) ty.B`
+
+	var any interface{} = []string{"a"}
+	_ = lib.Map(strconv.Itoa, any).([]string)
+	_ = lib.Map(nil, []int{1})
+
+	add := func(a, b int) int { return a + b }
+	_, _ = lib.Reduce(add, []int{1})
+	_, _ = lib.Reduce(add, []float64{1}) // want `type variable ty.A is bound to int, but got float64`
+
+	_ = lib.Keys(map[string]int{}).([]string)
+	_ = lib.Keys(map[string]int{}).([]int) // want `lib.Keys returns \[\]string here`
+	_ = lib.Chunk([]int{1}, 2).([][]int)
+	_ = lib.Chunk([]int{1}, 2).([]int) // want `lib.Chunk returns \[\]\[\]int here`
+
+	p := new(lib.Pair)
+	p.Set("a", 1)
+	p.Swap(1, 2)
+	p.Swap(1, "two") // want `\(\*lib.Pair\).Swap: argument 2 of type string does not match .*: type variable ty.B is bound to int, but got string`
+	_ = p.Pairs([]int{1}).(map[int]int)
+	_ = p.Pairs([]int{1}).(map[int]string) // want `\(\*lib.Pair\).Pairs returns map\[int\]int here`
+}
+
+func local(f, xs interface{}) interface{} {
+	return lib.Map(f, xs)
+}
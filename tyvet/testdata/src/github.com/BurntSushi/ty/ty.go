@@ -0,0 +1,27 @@
+// Package ty is a stub of the real package with just enough to write
+// parametric functions.
+package ty
+
+import "reflect"
+
+type TypeVariable struct {
+	noImitation struct{}
+}
+
+type A TypeVariable
+type B TypeVariable
+
+type Typed struct {
+	Args    []reflect.Value
+	Returns []reflect.Type
+}
+
+func Check(f interface{}, as ...interface{}) *Typed {
+	return nil
+}
+
+type Instance struct{}
+
+func (inst *Instance) Check(f interface{}, as ...interface{}) *Typed {
+	return nil
+}
@@ -0,0 +1,55 @@
+package lib
+
+import "github.com/BurntSushi/ty"
+
+func Map(f, xs interface{}) interface{} {
+	chk := ty.Check(
+		new(func(func(ty.A) ty.B, []ty.A) []ty.B),
+		f, xs)
+	return chk.Returns[0]
+}
+
+func Reduce(f, xs interface{}) (interface{}, bool) {
+	chk := ty.Check(
+		new(func(func(ty.A, ty.A) ty.A, []ty.A) ty.A),
+		f, xs)
+	return chk.Args[0], true
+}
+
+func Keys(m interface{}) interface{} {
+	chk := ty.Check(
+		new(func(map[ty.A]ty.B) []ty.A),
+		m)
+	return chk.Returns[0]
+}
+
+// Pair is a parametric type whose methods check their arguments with its
+// instance.
+type Pair struct {
+	inst *ty.Instance
+}
+
+func (p *Pair) Set(first, second interface{}) {
+	p.inst.Check(new(func(ty.A, ty.B)), first, second)
+}
+
+func (p *Pair) Swap(old, repl interface{}) bool {
+	p.inst.Check(new(func(ty.B, ty.B)), old, repl)
+	return true
+}
+
+// Pairs checks its parameter with `ty.Check`.
+func (p *Pair) Pairs(xs interface{}) interface{} {
+	chk := ty.Check(
+		new(func([]ty.A) map[ty.A]ty.A),
+		xs)
+	return chk.Returns[0]
+}
+
+// Chunk checks only some of its parameters.
+func Chunk(xs interface{}, n int) interface{} {
+	chk := ty.Check(
+		new(func([]ty.A, int) [][]ty.A),
+		xs, n)
+	return chk.Returns[0]
+}
@@ -0,0 +1,288 @@
+// Package tyvet defines an analyzer that reports type errors in calls to
+// parametric functions written with `ty.Check`, such as those in the `fun`
+// and `data` packages, before they panic at run time.
+//
+// A parametric function is recognized by a call to `ty.Check` in its body
+// whose first argument is a `new(func(...))` giving its parametric type and
+// whose remaining arguments are parameters of the function. For example,
+// the parametric type of
+//
+//	func Map(f, xs interface{}) interface{} {
+//		chk := ty.Check(
+//			new(func(func(ty.A) ty.B, []ty.A) []ty.B),
+//			f, xs)
+//		...
+//	}
+//
+// is `func(func(A) B, []A) []B`. Its results are assumed to correspond to
+// the results of the parametric type, as long as they are `interface{}`.
+//
+// At each call of a parametric function, the analyzer unifies the static
+// types of the arguments with the parametric type in the same way as
+// `ty.Check`, and reports arguments that `ty.Check` would reject. If the
+// result of the call is immediately type asserted, e.g.,
+//
+//	strs := fun.Map(strconv.Itoa, []int{1, 2, 3}).([]int)
+//
+// the analyzer also reports assertions to any type other than the
+// instantiated result type. Arguments whose static type is an interface are
+// skipped, since only their dynamic type is checked at run time.
+//
+// Methods are recognized in the same way, including methods of parametric
+// types such as `data.SyncOrdMap` that check their arguments with
+// `ty.Instance.Check`. The types bound by an instance are only known at run
+// time, so the arguments of a call of such a method are only checked
+// against each other. For example, the analyzer reports
+//
+//	sm.CompareAndSwap("key", 1, "one")
+//
+// since the old and new values must have the same type, but not
+// `sm.Put("key", 1)` for a map from strings to strings.
+//
+// The analyzer can be run with `go vet` using the `tyvet` command:
+//
+//	go install github.com/BurntSushi/ty/cmd/tyvet
+//	go vet -vettool=$(which tyvet) ./...
+package tyvet
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer reports calls of parametric functions that would panic with a
+// `ty.TypeError` or fail a type assertion of their result.
+var Analyzer = &analysis.Analyzer{
+	Name:      "tyvet",
+	Doc:       "check calls of parametric functions written with ty.Check",
+	Run:       run,
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	FactTypes: []analysis.Fact{new(parametric)},
+}
+
+// parametric is a fact about a parametric function: its parametric type and
+// how the arguments given to `ty.Check` correspond to its parameters.
+type parametric struct {
+	Sig node
+
+	// Params maps each parameter of the parametric type to the index of the
+	// parameter of the function that is passed for it.
+	Params []int
+
+	// Results maps each result of the function to the index of the result
+	// of the parametric type that it returns, or -1.
+	Results []int
+}
+
+func (*parametric) AFact() {}
+
+func (p *parametric) String() string {
+	return fmt.Sprintf("parametric(%s)", p.Sig)
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	insp.Preorder([]ast.Node{(*ast.FuncDecl)(nil)}, func(n ast.Node) {
+		decl := n.(*ast.FuncDecl)
+		if decl.Body == nil {
+			return
+		}
+		fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+		if !ok {
+			return
+		}
+		if fact := parametricOf(pass, fn, decl); fact != nil {
+			pass.ExportObjectFact(fn, fact)
+		}
+	})
+
+	// A type assertion is visited before the call it asserts, which is
+	// then skipped.
+	asserted := make(map[*ast.CallExpr]bool)
+	calls := []ast.Node{(*ast.TypeAssertExpr)(nil), (*ast.CallExpr)(nil)}
+	insp.Preorder(calls, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.TypeAssertExpr:
+			if call, ok := ast.Unparen(n.X).(*ast.CallExpr); ok {
+				asserted[call] = true
+				checkCall(pass, call, n)
+			}
+		case *ast.CallExpr:
+			if !asserted[n] {
+				checkCall(pass, n, nil)
+			}
+		}
+	})
+	return nil, nil
+}
+
+// parametricOf returns the parametric type of `fn`, or nil if its body does
+// not check its parameters with `ty.Check`.
+func parametricOf(
+	pass *analysis.Pass,
+	fn *types.Func,
+	decl *ast.FuncDecl,
+) *parametric {
+	var check *ast.CallExpr
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok && check == nil {
+			if isTyCheck(pass.TypesInfo, call) {
+				check = call
+			}
+		}
+		return check == nil
+	})
+	if check == nil {
+		return nil
+	}
+
+	ptr, ok := pass.TypesInfo.TypeOf(check.Args[0]).(*types.Pointer)
+	if !ok {
+		return nil
+	}
+	sig, ok := ptr.Elem().Underlying().(*types.Signature)
+	if !ok || sig.Params().Len() != len(check.Args)-1 {
+		return nil
+	}
+
+	fsig := fn.Type().(*types.Signature)
+	params := make([]int, 0, len(check.Args)-1)
+	for _, arg := range check.Args[1:] {
+		id, ok := ast.Unparen(arg).(*ast.Ident)
+		if !ok {
+			return nil
+		}
+		i := paramIndex(fsig, pass.TypesInfo.Uses[id])
+		if i < 0 {
+			return nil
+		}
+		params = append(params, i)
+	}
+
+	results := make([]int, fsig.Results().Len())
+	for i := range results {
+		results[i] = -1
+		t := fsig.Results().At(i).Type()
+		iface, ok := t.Underlying().(*types.Interface)
+		if ok && iface.Empty() && i < sig.Results().Len() {
+			results[i] = i
+		}
+	}
+	return &parametric{Sig: nodeOf(sig), Params: params, Results: results}
+}
+
+// checkCall reports the arguments of `call` that do not unify with the
+// parametric type of the called function, and `assert` if it asserts the
+// result to the wrong type.
+func checkCall(
+	pass *analysis.Pass,
+	call *ast.CallExpr,
+	assert *ast.TypeAssertExpr,
+) {
+	fn, ok := calledFunc(pass.TypesInfo, call)
+	if !ok {
+		return
+	}
+	var fact parametric
+	if !pass.ImportObjectFact(fn, &fact) {
+		return
+	}
+	if call.Ellipsis.IsValid() {
+		return
+	}
+
+	name := fn.Name()
+	if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+		name = "(" + typeString(recv.Type()) + ")." + name
+	} else if fn.Pkg() != nil && fn.Pkg() != pass.Pkg {
+		name = fn.Pkg().Name() + "." + name
+	}
+	e := make(env)
+	for i, pi := range fact.Params {
+		if pi >= len(call.Args) {
+			return
+		}
+		arg := call.Args[pi]
+		t := pass.TypesInfo.TypeOf(arg)
+		if t == nil || types.IsInterface(t) || isUntypedNil(t) {
+			continue
+		}
+		if err := e.unify(fact.Sig.Elems[i], types.Default(t)); err != nil {
+			pass.Reportf(arg.Pos(), "%s: argument %d of type %s does not "+
+				"match parametric type %s: %s",
+				name, pi+1, typeString(t), fact.Sig, err)
+			return
+		}
+	}
+
+	if assert == nil || assert.Type == nil || len(fact.Results) != 1 {
+		return
+	}
+	ri := fact.Results[0]
+	if ri < 0 {
+		return
+	}
+	want := e.subst(fact.Sig.Elems[fact.Sig.NumIn+ri])
+	got := pass.TypesInfo.TypeOf(assert.Type)
+	if want != nil && got != nil && !types.Identical(want, got) {
+		pass.Reportf(assert.Type.Pos(), "%s returns %s here, but its "+
+			"result is asserted to %s", name, typeString(want), typeString(got))
+	}
+}
+
+// isTyCheck returns true if `call` calls `ty.Check` or `ty.Instance.Check`
+// with a parametric type.
+func isTyCheck(info *types.Info, call *ast.CallExpr) bool {
+	fn, ok := calledFunc(info, call)
+	if !ok || fn.Name() != "Check" || fn.Pkg() == nil ||
+		fn.Pkg().Path() != tyPkgPath || len(call.Args) == 0 {
+		return false
+	}
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return true
+	}
+	ptr, ok := recv.Type().(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := ptr.Elem().(*types.Named)
+	return ok && named.Obj().Name() == "Instance"
+}
+
+// calledFunc returns the package-level function or the method called by
+// `call`.
+func calledFunc(info *types.Info, call *ast.CallExpr) (*types.Func, bool) {
+	var id *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	default:
+		return nil, false
+	}
+	fn, ok := info.Uses[id].(*types.Func)
+	return fn, ok
+}
+
+// paramIndex returns the index of the parameter `obj` of `sig`, or -1.
+func paramIndex(sig *types.Signature, obj types.Object) int {
+	for i := 0; i < sig.Params().Len(); i++ {
+		if sig.Params().At(i) == obj {
+			return i
+		}
+	}
+	return -1
+}
+
+func isUntypedNil(t types.Type) bool {
+	b, ok := t.(*types.Basic)
+	return ok && b.Kind() == types.UntypedNil
+}
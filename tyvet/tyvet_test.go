@@ -0,0 +1,12 @@
+package tyvet_test
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/ty/tyvet"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), tyvet.Analyzer, "a")
+}
@@ -0,0 +1,123 @@
+package ty
+
+import (
+	"reflect"
+	"runtime"
+)
+
+// VerifyReturns checks that the values `vals` returned by a parametric
+// function have the types in `Returns`, which `Check` inferred from its
+// arguments. It is meant to be called by the parametric function just
+// before it returns, with all of its results, e.g.,
+//
+//	chk := ty.Check(
+//		new(func(func(ty.A) ty.B, []ty.A) []ty.B),
+//		f, xs)
+//	...
+//	ys := vys.Interface()
+//	chk.VerifyReturns(ys)
+//	return ys
+//
+// so that a bug in the function is reported where it is, rather than when
+// the caller type asserts the result.
+//
+// VerifyReturns only checks anything in programs built with the `tydebug`
+// build tag, e.g., `go test -tags tydebug`. Otherwise, it does nothing. When
+// it checks, it panics with a `TypeError` if the number of values differs
+// from the number of return types, or if a value does not have its return
+// type. A nil value may only be returned for an interface type.
+func (t *Typed) VerifyReturns(vals ...interface{}) {
+	if debug {
+		t.verifyReturns(vals)
+	}
+}
+
+func (t *Typed) verifyReturns(vals []interface{}) {
+	if len(vals) != len(t.Returns) {
+		ppe("%s returned %d values but has %d return types.",
+			signatureOf(t.caller, t.sig), len(vals), len(t.Returns))
+	}
+	for i, val := range vals {
+		if err := returnsType(val, t.Returns[i]); err != "" {
+			ppe("%s returned a value of the wrong type: result %d %s.",
+				signatureOf(t.caller, t.sig), i+1, err)
+		}
+	}
+}
+
+// returnsType returns a description of why `val` cannot be returned as a
+// result of type `t`, or an empty string if it can.
+func returnsType(val interface{}, t reflect.Type) string {
+	tval := reflect.TypeOf(val)
+	switch {
+	case tval == nil && t.Kind() == reflect.Interface:
+		return ""
+	case tval == nil:
+		return "is nil but has type '" + t.String() + "'"
+	case t.Kind() == reflect.Interface && tval.Implements(t):
+		return ""
+	case tval != t:
+		return "has type '" + tval.String() + "' but should have type '" +
+			t.String() + "'"
+	}
+	return ""
+}
+
+// Checked returns the implementation `impl` of a parametric function whose
+// parametric type is given by `sig`, a pointer to a nil function like the
+// `f` given to `Check`. In programs built with the `tydebug` build tag, the
+// result is a function with the same type as `impl` that checks its
+// arguments with `Check` and its results with `Typed.VerifyReturns` on
+// every call. Otherwise, it is `impl` itself.
+//
+// For example, a test can check that an implementation of `Map` returns
+// values of the types its parametric type promises with
+//
+//	checkedMap := ty.Checked(
+//		new(func(func(ty.A) ty.B, []ty.A) []ty.B),
+//		Map,
+//	).(func(f, xs interface{}) interface{})
+//
+// Checked panics with a `TypeError` if `sig` is not a pointer to a function
+// or if `impl` is not a function with as many parameters and results as
+// `sig`. (Its results are checked in all of `sig`, so results that are not
+// parametric should be given their Go types in `sig`.)
+func Checked(sig, impl interface{}) interface{} {
+	tsig := funcTypeOf("sig", sig)
+	vimpl := reflect.ValueOf(impl)
+	if vimpl.Kind() != reflect.Func || vimpl.IsNil() {
+		ppe("The implementation must be a function, but it is a '%T'.", impl)
+	}
+	timpl := vimpl.Type()
+	if timpl.NumIn() != tsig.NumIn() || timpl.NumOut() != tsig.NumOut() ||
+		timpl.IsVariadic() != tsig.IsVariadic() {
+		ppe("Implementation of type '%s' does not have the parametric "+
+			"type '%s'.", timpl, tsig)
+	}
+	if !debug {
+		return impl
+	}
+
+	checked := func(in []reflect.Value) []reflect.Value {
+		args := make([]interface{}, len(in))
+		for i := range in {
+			args[i] = in[i].Interface()
+		}
+		chk := Check(reflect.New(tsig).Interface(), args...)
+		chk.caller = shortFuncName(runtime.FuncForPC(vimpl.Pointer()).Name())
+
+		var results []reflect.Value
+		if timpl.IsVariadic() {
+			results = vimpl.CallSlice(in)
+		} else {
+			results = vimpl.Call(in)
+		}
+		vals := make([]interface{}, len(results))
+		for i := range results {
+			vals[i] = results[i].Interface()
+		}
+		chk.verifyReturns(vals)
+		return results
+	}
+	return reflect.MakeFunc(timpl, checked).Interface()
+}
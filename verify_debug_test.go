@@ -0,0 +1,56 @@
+//go:build tydebug
+// +build tydebug
+
+package ty_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/BurntSushi/ty"
+)
+
+func badMap(f, xs interface{}) interface{} {
+	chk := ty.Check(new(func(func(ty.A) ty.B, []ty.A) []ty.B), f, xs)
+	vys := reflect.MakeSlice(chk.Args[1].Type(), 0, 0).Interface()
+	chk.VerifyReturns(vys)
+	return vys
+}
+
+func TestVerifyReturnsWrongType(t *testing.T) {
+	assertTypeError(t, "func(func(ty.A) ty.B, []ty.A) []ty.B returned a "+
+		"value of the wrong type: result 1 has type '[]int' but should "+
+		"have type '[]string'", func() {
+		badMap(func(x int) string { return "" }, []int{1})
+	})
+}
+
+func TestVerifyReturnsNil(t *testing.T) {
+	chk := ty.Check(new(func(ty.A) []ty.A), 5)
+	assertTypeError(t, "result 1 is nil but has type '[]int'", func() {
+		chk.VerifyReturns(nil)
+	})
+}
+
+func TestVerifyReturnsCount(t *testing.T) {
+	chk := ty.Check(new(func(ty.A) (ty.A, bool)), 5)
+	assertTypeError(t, "returned 1 values but has 2 return types", func() {
+		chk.VerifyReturns(5)
+	})
+}
+
+func TestCheckedWrongType(t *testing.T) {
+	checked := ty.Checked(
+		new(func(ty.A) []ty.A),
+		func(x interface{}) interface{} { return []string{"x"} },
+	).(func(interface{}) interface{})
+	checked("x")
+	assertTypeError(t, "should have type '[]int'", func() {
+		checked(5)
+	})
+	assertTypeError(t, "Type error when unifying", func() {
+		ty.Checked(new(func(ty.A, ty.A)),
+			func(x, y interface{}) {},
+		).(func(x, y interface{}))(1, "y")
+	})
+}
@@ -0,0 +1,49 @@
+package ty_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/BurntSushi/ty"
+)
+
+func verifiedMap(f, xs interface{}) interface{} {
+	chk := ty.Check(new(func(func(ty.A) ty.B, []ty.A) []ty.B), f, xs)
+	vf, vxs, tys := chk.Args[0], chk.Args[1], chk.Returns[0]
+
+	vys := reflect.MakeSlice(tys, vxs.Len(), vxs.Len())
+	for i := 0; i < vxs.Len(); i++ {
+		vys.Index(i).Set(vf.Call([]reflect.Value{vxs.Index(i)})[0])
+	}
+	ys := vys.Interface()
+	chk.VerifyReturns(ys)
+	return ys
+}
+
+func TestVerifyReturns(t *testing.T) {
+	ys := verifiedMap(func(x int) string { return "x" }, []int{1, 2})
+	if got := ys.([]string); len(got) != 2 {
+		t.Fatalf("Expected two strings but got %v.", got)
+	}
+
+	chk := ty.Check(new(func(ty.A) (error, bool)), 5)
+	chk.VerifyReturns(nil, true)
+}
+
+func TestChecked(t *testing.T) {
+	checkedMap := ty.Checked(
+		new(func(func(ty.A) ty.B, []ty.A) []ty.B),
+		mapImpl,
+	).(func(f, xs interface{}) interface{})
+	ys := checkedMap(func(x int) int { return x * 2 }, []int{1, 2})
+	if got := ys.([]int); got[0] != 2 || got[1] != 4 {
+		t.Fatalf("Expected [2 4] but got %v.", got)
+	}
+
+	assertTypeError(t, "does not have the parametric type", func() {
+		ty.Checked(new(func(ty.A) ty.A), mapImpl)
+	})
+	assertTypeError(t, "must be a function", func() {
+		ty.Checked(new(func(ty.A) ty.A), 5)
+	})
+}